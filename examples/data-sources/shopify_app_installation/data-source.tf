@@ -0,0 +1,5 @@
+data "shopify_app_installation" "current" {}
+
+output "granted_scopes" {
+  value = data.shopify_app_installation.current.access_scopes
+}
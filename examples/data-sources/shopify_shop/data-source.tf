@@ -0,0 +1,9 @@
+data "shopify_shop" "current" {}
+
+output "shop_timezone" {
+  value = data.shopify_shop.current.iana_timezone
+}
+
+output "shop_currencies" {
+  value = data.shopify_shop.current.enabled_presentment_currencies
+}
@@ -0,0 +1,6 @@
+data "shopify_webhook_subscriptions" "all" {}
+
+data "shopify_webhook_subscriptions" "orders" {
+  topics = ["ORDERS_CREATE", "ORDERS_PAID"]
+  format = "JSON"
+}
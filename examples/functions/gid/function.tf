@@ -0,0 +1,3 @@
+output "payment_customization_id" {
+  value = provider::shopify::gid("PaymentCustomization", "1")
+}
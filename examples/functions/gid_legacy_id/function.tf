@@ -0,0 +1,3 @@
+output "discount_legacy_id" {
+  value = provider::shopify::gid_legacy_id(shopify_discount.example.id)
+}
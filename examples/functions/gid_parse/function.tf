@@ -0,0 +1,3 @@
+output "discount_type" {
+  value = provider::shopify::gid_parse(shopify_discount.example.id).type
+}
@@ -0,0 +1,13 @@
+locals {
+  minimum_spend = provider::shopify::metafield_value("money", {
+    amount        = 50
+    currency_code = "USD"
+  })
+
+  eligible_tags = provider::shopify::metafield_value("list.single_line_text_field", ["vip", "wholesale"])
+
+  function_config = provider::shopify::metafield_value("json", {
+    percentage = 10
+    message    = "VIP discount"
+  })
+}
@@ -0,0 +1,8 @@
+list "shopify_delivery" "example" {
+  provider = shopify
+
+  config {
+    function_id = "<UUID>"
+    enabled     = true
+  }
+}
@@ -0,0 +1,7 @@
+list "shopify_discount" "example" {
+  provider = shopify
+
+  config {
+    status = "ACTIVE"
+  }
+}
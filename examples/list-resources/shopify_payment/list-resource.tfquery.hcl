@@ -0,0 +1,8 @@
+list "shopify_payment" "example" {
+  provider = shopify
+
+  config {
+    function_id = "<UUID>"
+    enabled     = true
+  }
+}
@@ -0,0 +1,8 @@
+list "shopify_pubsub_webhook" "example" {
+  provider = shopify
+
+  config {
+    topics = ["ORDERS_CREATE", "ORDERS_UPDATED"]
+    format = "JSON"
+  }
+}
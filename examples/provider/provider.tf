@@ -10,5 +10,17 @@ terraform {
 provider "shopify" {
   store_domain       = "<store>.myshopify.com"
   store_access_token = "<access_token>"
-  store_api_version  = "2024-07"
+  store_api_version  = "2026-10"
+}
+
+# Authenticate with the app's credentials instead of a long-lived token.
+provider "shopify" {
+  alias             = "oauth"
+  store_domain      = "<store>.myshopify.com"
+  store_api_version = "2026-10"
+
+  oauth {
+    client_id     = "<client_id>"
+    client_secret = "<client_secret>"
+  }
 }
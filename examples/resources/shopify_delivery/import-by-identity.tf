@@ -0,0 +1,6 @@
+import {
+  to = shopify_delivery.example
+  identity = {
+    id = "gid://shopify/DeliveryCustomization/1"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = shopify_delivery.example
+  id = "gid://shopify/DeliveryCustomization/1"
+}
@@ -1 +1 @@
-terraform import shopify_delivery.example <delivery_id>,<function_id>
+terraform import shopify_delivery.example <delivery_id>
@@ -0,0 +1,6 @@
+import {
+  to = shopify_discount.example
+  identity = {
+    id = "gid://shopify/DiscountAutomaticNode/1"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = shopify_discount.example
+  id = "gid://shopify/DiscountAutomaticNode/1"
+}
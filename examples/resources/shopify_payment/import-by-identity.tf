@@ -0,0 +1,6 @@
+import {
+  to = shopify_payment.example
+  identity = {
+    id = "gid://shopify/PaymentCustomization/1"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = shopify_payment.example
+  id = "gid://shopify/PaymentCustomization/1"
+}
@@ -1 +1 @@
-terraform import shopify_payment.example <payment_id>,<function_id>
+terraform import shopify_payment.example <payment_id>
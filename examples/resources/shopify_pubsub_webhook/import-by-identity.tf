@@ -0,0 +1,6 @@
+import {
+  to = shopify_pubsub_webhook.example
+  identity = {
+    id = "gid://shopify/WebhookSubscription/1"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = shopify_pubsub_webhook.example
+  id = "gid://shopify/WebhookSubscription/1"
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+// Run runs the export subcommand with args, the arguments that follow it.
+// Like the provider, it connects to the store with the SHOPIFY_* environment
+// variables.
+func Run(ctx context.Context, args []string, providerVersion string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-shopify export [-out dir]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Writes Terraform configuration and import blocks for the store's discounts, customizations and Pub/Sub webhooks.")
+		fmt.Fprintln(stderr, "Objects no resource manages, like validations and cart transforms, are listed as skipped.")
+		fmt.Fprintln(stderr, "The store is read with the same SHOPIFY_* environment variables as the provider uses.")
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+
+	out := flags.String("out", ".", "directory to write the .tf files to")
+	if err := flags.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	storeDomain := os.Getenv("SHOPIFY_STORE_DOMAIN")
+	client, err := clientFromEnv(ctx, storeDomain, stderr)
+	if err != nil {
+		return err
+	}
+
+	result, err := Export(ctx, client, storeDomain, providerVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, skipped := range result.Skipped {
+		fmt.Fprintf(stderr, "Skipped %s\n", skipped)
+	}
+
+	return result.Write(*out)
+}
+
+// clientFromEnv returns a client for storeDomain configured, as the provider
+// is when its configuration leaves them unset, by the SHOPIFY_* environment
+// variables.
+func clientFromEnv(ctx context.Context, storeDomain string, stderr io.Writer) (*shopify.ShopifyAdminClinetImpl, error) {
+	var errs []error
+
+	if storeDomain == "" {
+		errs = append(errs, errors.New("SHOPIFY_STORE_DOMAIN is not set"))
+	}
+
+	storeAccessToken := os.Getenv("SHOPIFY_STORE_ACCESS_TOKEN")
+
+	var opts []shopify.Option
+	if storeAccessToken == "" {
+		oauth := shopify.OAuth{
+			ClientID:     os.Getenv("SHOPIFY_CLIENT_ID"),
+			ClientSecret: os.Getenv("SHOPIFY_CLIENT_SECRET"),
+			SessionToken: os.Getenv("SHOPIFY_SESSION_TOKEN"),
+		}
+
+		switch {
+		case oauth.ClientID == "":
+			errs = append(errs, errors.New("SHOPIFY_STORE_ACCESS_TOKEN, or SHOPIFY_CLIENT_ID and SHOPIFY_CLIENT_SECRET, are not set"))
+		case oauth.ClientSecret == "":
+			errs = append(errs, errors.New("SHOPIFY_CLIENT_SECRET is not set"))
+		default:
+			opts = append(opts, shopify.WithOAuth(oauth))
+		}
+	}
+
+	storeApiVersion := os.Getenv("SHOPIFY_STORE_API_VERSION")
+	if storeApiVersion == "" {
+		errs = append(errs, errors.New("SHOPIFY_STORE_API_VERSION is not set"))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	switch support, err := shopify.CheckApiVersion(storeApiVersion, time.Now()); {
+	case err != nil:
+		return nil, err
+	case support == shopify.ApiVersionUnsupported:
+		fmt.Fprintf(
+			stderr,
+			"Warning: Shopify stopped supporting API version %s on %s and answers its requests with the oldest supported version instead.\n",
+			storeApiVersion,
+			shopify.ApiVersionSupportEnds(storeApiVersion).Format(time.DateOnly),
+		)
+	}
+
+	client := shopify.New(storeDomain, storeAccessToken, storeApiVersion, opts...)
+
+	if storeAccessToken == "" {
+		if err := client.Authenticate(ctx); err != nil {
+			return nil, fmt.Errorf("authenticating with Shopify: %w", err)
+		}
+	}
+
+	return client, nil
+}
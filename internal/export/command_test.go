@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_MissingEnv(t *testing.T) {
+	for _, key := range []string{
+		"SHOPIFY_STORE_DOMAIN",
+		"SHOPIFY_STORE_ACCESS_TOKEN",
+		"SHOPIFY_CLIENT_ID",
+		"SHOPIFY_CLIENT_SECRET",
+		"SHOPIFY_SESSION_TOKEN",
+		"SHOPIFY_STORE_API_VERSION",
+	} {
+		t.Setenv(key, "")
+	}
+
+	err := Run(context.Background(), []string{"-out", t.TempDir()}, "test", &bytes.Buffer{})
+
+	assert.ErrorContains(t, err, "SHOPIFY_STORE_DOMAIN is not set")
+	assert.ErrorContains(t, err, "SHOPIFY_STORE_ACCESS_TOKEN, or SHOPIFY_CLIENT_ID and SHOPIFY_CLIENT_SECRET, are not set")
+	assert.ErrorContains(t, err, "SHOPIFY_STORE_API_VERSION is not set")
+}
+
+func TestRun_MissingClientSecret(t *testing.T) {
+	t.Setenv("SHOPIFY_STORE_DOMAIN", "test.myshopify.com")
+	t.Setenv("SHOPIFY_STORE_ACCESS_TOKEN", "")
+	t.Setenv("SHOPIFY_CLIENT_ID", "client")
+	t.Setenv("SHOPIFY_CLIENT_SECRET", "")
+	t.Setenv("SHOPIFY_STORE_API_VERSION", "2024-07")
+
+	err := Run(context.Background(), nil, "test", &bytes.Buffer{})
+
+	assert.EqualError(t, err, "SHOPIFY_CLIENT_SECRET is not set")
+}
+
+func TestRun_UnexpectedArguments(t *testing.T) {
+	var stderr bytes.Buffer
+	err := Run(context.Background(), []string{"discounts"}, "test", &stderr)
+
+	assert.EqualError(t, err, "unexpected arguments: discounts")
+	assert.Contains(t, stderr.String(), "Usage: terraform-provider-shopify export")
+}
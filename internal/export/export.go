@@ -0,0 +1,406 @@
+// Package export generates Terraform configuration, with import blocks, for
+// the objects a store already has, so that the provider can take over
+// managing them.
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const providerSource = "pseudomonarchia/terraform-provider-shopify"
+
+// Result is the configuration generated for a store.
+type Result struct {
+	// Files maps file names to their content. Files that would be empty are
+	// left out.
+	Files map[string][]byte
+
+	// Skipped describes the store's objects, and kinds of objects, that no
+	// resource of the provider manages, and that have no configuration for
+	// that reason.
+	Skipped []string
+}
+
+// exporter collects the blocks of the files of a Result.
+type exporter struct {
+	client *shopify.ShopifyAdminClinetImpl
+
+	// functions holds the store's functions, in catalogue order.
+	functions []shopify.FunctionNode
+
+	// functionRefs maps the IDs of the functions referenced so far to
+	// their data source.
+	functionRefs map[string]hcl.Traversal
+
+	// labels holds the labels taken so far, by block type.
+	labels map[string]map[string]bool
+
+	files   map[string]*hclwrite.File
+	skipped []string
+}
+
+// Export generates the configuration for the store's automatic app
+// discounts, payment and delivery customizations and Pub/Sub webhook
+// subscriptions:
+//
+//   - provider.tf requires and configures the provider
+//   - functions.tf reads the functions they are backed by
+//   - discounts.tf, payments.tf, deliveries.tf and pubsub_webhooks.tf hold
+//     their resources
+//   - imports.tf imports them
+//
+// The store's validations and cart transforms, which functions back too,
+// have no resource and are listed in Result.Skipped.
+//
+// The function_id of a resource references a shopify_function data source
+// when its function can be looked up by title and app title, and is the
+// function's ID otherwise.
+func Export(
+	ctx context.Context,
+	client *shopify.ShopifyAdminClinetImpl,
+	storeDomain string,
+	providerVersion string,
+) (*Result, error) {
+	e := &exporter{
+		client:       client,
+		functionRefs: map[string]hcl.Traversal{},
+		labels:       map[string]map[string]bool{},
+		files:        map[string]*hclwrite.File{},
+	}
+
+	functions, err := client.Function.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing functions: %w", err)
+	}
+
+	e.functions = functions.Nodes
+
+	e.provider(storeDomain, providerVersion)
+
+	if err := e.discounts(ctx); err != nil {
+		return nil, fmt.Errorf("listing automatic app discounts: %w", err)
+	}
+
+	if err := e.payments(ctx); err != nil {
+		return nil, fmt.Errorf("listing payment customizations: %w", err)
+	}
+
+	if err := e.deliveries(ctx); err != nil {
+		return nil, fmt.Errorf("listing delivery customizations: %w", err)
+	}
+
+	if err := e.pubsubWebhooks(ctx); err != nil {
+		return nil, fmt.Errorf("listing webhook subscriptions: %w", err)
+	}
+
+	if err := e.validations(ctx); err != nil {
+		return nil, fmt.Errorf("listing validations: %w", err)
+	}
+
+	if err := e.cartTransforms(ctx); err != nil {
+		return nil, fmt.Errorf("listing cart transforms: %w", err)
+	}
+
+	result := &Result{
+		Files:   map[string][]byte{},
+		Skipped: e.skipped,
+	}
+
+	for name, f := range e.files {
+		result.Files[name] = hclwrite.Format(f.Bytes())
+	}
+
+	return result, nil
+}
+
+// Write writes the result's files to dir, creating it if needed. It doesn't
+// overwrite existing files: it fails before writing any file if one of them
+// already exists.
+func (r *Result) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for name := range r.Files {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+
+	for name, content := range r.Files {
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+
+		_, err = f.Write(content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) provider(storeDomain string, providerVersion string) {
+	body := e.file("provider.tf")
+
+	requiredProviders := body.AppendNewBlock("terraform", nil).Body().AppendNewBlock("required_providers", nil).Body()
+	requiredProviders.SetAttributeValue("shopify", cty.ObjectVal(map[string]cty.Value{
+		"source":  cty.StringVal(providerSource),
+		"version": cty.StringVal(providerVersion),
+	}))
+
+	// The access token, or OAuth client, is left to the environment.
+	body.AppendNewline()
+	provider := body.AppendNewBlock("provider", []string{"shopify"}).Body()
+	provider.SetAttributeValue("store_domain", cty.StringVal(storeDomain))
+	provider.SetAttributeValue("store_api_version", cty.StringVal(e.client.ApiVersion()))
+}
+
+func (e *exporter) discounts(ctx context.Context) error {
+	discounts, err := e.client.Discount.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range discounts {
+		body := e.resource("discounts.tf", "shopify_discount", d.Title, d.ID)
+		e.setFunctionID(body, d.FunctionID)
+		body.SetAttributeValue("title", cty.StringVal(d.Title))
+		body.SetAttributeValue("starts_at", cty.StringVal(d.StartsAt))
+		if d.EndsAt != "" {
+			body.SetAttributeValue("ends_at", cty.StringVal(d.EndsAt))
+		}
+
+		combinesWith := d.CombinesWith
+		if combinesWith == nil {
+			combinesWith = &shopify.DiscountCombinesWith{}
+		}
+
+		body.SetAttributeValue("combines_with", cty.ObjectVal(map[string]cty.Value{
+			"order_discounts":    cty.BoolVal(combinesWith.OrderDiscounts),
+			"product_discounts":  cty.BoolVal(combinesWith.ProductDiscounts),
+			"shipping_discounts": cty.BoolVal(combinesWith.ShippingDiscounts),
+		}))
+	}
+
+	return nil
+}
+
+func (e *exporter) payments(ctx context.Context) error {
+	payments, err := e.client.Payment.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range payments {
+		body := e.resource("payments.tf", "shopify_payment", p.Title, p.ID)
+		e.setFunctionID(body, p.FunctionID)
+		body.SetAttributeValue("title", cty.StringVal(p.Title))
+		body.SetAttributeValue("enabled", cty.BoolVal(p.Enabled))
+	}
+
+	return nil
+}
+
+func (e *exporter) deliveries(ctx context.Context) error {
+	deliveries, err := e.client.Delivery.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range deliveries {
+		body := e.resource("deliveries.tf", "shopify_delivery", d.Title, d.ID)
+		e.setFunctionID(body, d.FunctionID)
+		body.SetAttributeValue("title", cty.StringVal(d.Title))
+		body.SetAttributeValue("enabled", cty.BoolVal(d.Enabled))
+	}
+
+	return nil
+}
+
+func (e *exporter) validations(ctx context.Context) error {
+	validations, err := e.client.Validation.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range validations {
+		e.skipped = append(e.skipped, fmt.Sprintf("validation %s (%s): validations have no resource", v.ID, v.Title))
+	}
+
+	return nil
+}
+
+func (e *exporter) cartTransforms(ctx context.Context) error {
+	cartTransforms, err := e.client.CartTransform.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cartTransforms {
+		e.skipped = append(e.skipped, fmt.Sprintf("cart transform %s: cart transforms have no resource", c.ID))
+	}
+
+	return nil
+}
+
+func (e *exporter) pubsubWebhooks(ctx context.Context) error {
+	subscriptions, err := e.client.WebhookSubscription.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range subscriptions {
+		if s.EndpointType != shopify.WebhookEndpointPubSub {
+			e.skipped = append(e.skipped, fmt.Sprintf(
+				"webhook subscription %s: %s endpoints have no resource",
+				s.ID,
+				s.EndpointType,
+			))
+
+			continue
+		}
+
+		body := e.resource("pubsub_webhooks.tf", "shopify_pubsub_webhook", s.Topic+"_"+s.PubSubTopic, s.ID)
+		body.SetAttributeValue("topic", cty.StringVal(s.Topic))
+		body.SetAttributeValue("format", cty.StringVal(s.Format))
+		body.SetAttributeValue("pubsub_project", cty.StringVal(s.PubSubProject))
+		body.SetAttributeValue("pubsub_topic", cty.StringVal(s.PubSubTopic))
+	}
+
+	return nil
+}
+
+// resource appends a resourceType block, labelled after name, to the file
+// named fileName and an import block for the object with the global ID id to
+// imports.tf. It returns the resource's body.
+func (e *exporter) resource(fileName string, resourceType string, name string, id string) *hclwrite.Body {
+	label := e.label(resourceType, name)
+
+	body := e.file(fileName)
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+
+	resource := body.AppendNewBlock("resource", []string{resourceType, label}).Body()
+
+	imports := e.file("imports.tf")
+	if len(imports.Blocks()) > 0 {
+		imports.AppendNewline()
+	}
+
+	importBlock := imports.AppendNewBlock("import", nil).Body()
+	importBlock.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	importBlock.SetAttributeValue("id", cty.StringVal(id))
+
+	return resource
+}
+
+// setFunctionID sets the function_id of a resource to a reference to the
+// function's data source, adding it to functions.tf the first time, or to
+// functionID when the data source can't find the function.
+func (e *exporter) setFunctionID(body *hclwrite.Body, functionID string) {
+	if ref, ok := e.functionRefs[functionID]; ok {
+		body.SetAttributeTraversal("function_id", ref)
+		return
+	}
+
+	i := slices.IndexFunc(e.functions, func(f shopify.FunctionNode) bool {
+		return f.ID == functionID
+	})
+
+	// The data source reads the first function with the title and app
+	// title, which may be another one.
+	if i < 0 || slices.IndexFunc(e.functions, func(f shopify.FunctionNode) bool {
+		return f.Title == e.functions[i].Title && f.APPName == e.functions[i].APPName
+	}) != i {
+		body.SetAttributeValue("function_id", cty.StringVal(functionID))
+		return
+	}
+
+	function := e.functions[i]
+	name := function.Handle
+	if name == "" {
+		name = function.Title
+	}
+
+	label := e.label("data.shopify_function", name)
+
+	functions := e.file("functions.tf")
+	if len(functions.Blocks()) > 0 {
+		functions.AppendNewline()
+	}
+
+	data := functions.AppendNewBlock("data", []string{"shopify_function", label}).Body()
+	data.SetAttributeValue("title", cty.StringVal(function.Title))
+	data.SetAttributeValue("app_title", cty.StringVal(function.APPName))
+	data.SetAttributeValue("api_type", cty.StringVal(function.APIType))
+
+	ref := hcl.Traversal{
+		hcl.TraverseRoot{Name: "data"},
+		hcl.TraverseAttr{Name: "shopify_function"},
+		hcl.TraverseAttr{Name: label},
+		hcl.TraverseAttr{Name: "id"},
+	}
+
+	e.functionRefs[functionID] = ref
+	body.SetAttributeTraversal("function_id", ref)
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// label returns a block label for a blockType named name, made of lower case
+// letters, digits and underscores, that no other blockType has.
+func (e *exporter) label(blockType string, name string) string {
+	label := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = strings.TrimPrefix(blockType[strings.LastIndex(blockType, ".")+1:], "shopify_") + "_" + label
+		label = strings.TrimSuffix(label, "_")
+	}
+
+	taken := e.labels[blockType]
+	if taken == nil {
+		taken = map[string]bool{}
+		e.labels[blockType] = taken
+	}
+
+	unique := label
+	for n := 2; taken[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", label, n)
+	}
+
+	taken[unique] = true
+
+	return unique
+}
+
+func (e *exporter) file(name string) *hclwrite.Body {
+	f, ok := e.files[name]
+	if !ok {
+		f = hclwrite.NewEmptyFile()
+		e.files[name] = f
+	}
+
+	return f.Body()
+}
@@ -0,0 +1,236 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer() *shopifytest.Server {
+	server := shopifytest.NewServer()
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "07224386-3c16-4f9e-b8ba-da049b6afc66",
+		Title:    "product-discount",
+		APIType:  "product_discounts",
+		AppTitle: "tf-testing",
+	})
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		Title:    "payment-customization",
+		APIType:  "payment_customization",
+		AppTitle: "tf-testing",
+	})
+
+	// The shopify_function data source can't tell this function from the
+	// one above.
+	server.AddFunction(shopifytest.Function{
+		ID:       "5b1f2a4e-8c1d-4c3e-9f0a-2d6b7e8f9a0b",
+		Title:    "payment-customization",
+		APIType:  "payment_customization",
+		AppTitle: "tf-testing",
+	})
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740",
+		Title:    "delivery-customization",
+		APIType:  "delivery_customization",
+		AppTitle: "tf-testing",
+	})
+
+	return server
+}
+
+func newTestClient(server *shopifytest.Server) *shopify.ShopifyAdminClinetImpl {
+	return shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+}
+
+func TestExport(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+
+	_, err := client.Discount.Create(ctx, "07224386-3c16-4f9e-b8ba-da049b6afc66", &shopify.DiscountNode{
+		Title:        "Spring sale",
+		StartsAt:     "2024-01-01T00:00:00Z",
+		EndsAt:       "2024-02-01T00:00:00Z",
+		CombinesWith: &shopify.DiscountCombinesWith{ShippingDiscounts: true},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Payment.Create(ctx, "f2e906be-a93a-48c6-a2cc-99c64e5ab816", &shopify.PaymentNode{Title: "Hide COD", Enabled: true})
+	require.NoError(t, err)
+
+	_, err = client.Payment.Create(ctx, "f2e906be-a93a-48c6-a2cc-99c64e5ab816", &shopify.PaymentNode{Title: "Hide COD"})
+	require.NoError(t, err)
+
+	_, err = client.Payment.Create(ctx, "5b1f2a4e-8c1d-4c3e-9f0a-2d6b7e8f9a0b", &shopify.PaymentNode{Title: "1 click"})
+	require.NoError(t, err)
+
+	_, err = client.Delivery.Create(ctx, "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740", &shopify.DeliveryNode{Title: "Rename express", Enabled: true})
+	require.NoError(t, err)
+
+	http := server.AddWebhookSubscription("ORDERS_CREATE", "JSON", "https://example.com/webhooks", "", "", "")
+	pubsub := server.AddWebhookSubscription("ORDERS_CREATE", "JSON", "", "", "project", "orders")
+
+	validation := server.AddValidation("9f1c3b7a-4e2d-4a8b-b6c5-1d0e2f3a4b5c", "Minimum quantity", true)
+	cartTransform := server.AddCartTransform("c7d8e9f0-1a2b-4c3d-8e4f-5a6b7c8d9e0f")
+
+	result, err := Export(ctx, client, shopifytest.StoreDomain, "0.0.4")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"webhook subscription " + http + ": HTTP endpoints have no resource",
+		"validation " + validation + " (Minimum quantity): validations have no resource",
+		"cart transform " + cartTransform + ": cart transforms have no resource",
+	}, result.Skipped)
+
+	files := map[string]string{}
+	for name, content := range result.Files {
+		files[name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"provider.tf": `terraform {
+  required_providers {
+    shopify = {
+      source  = "pseudomonarchia/terraform-provider-shopify"
+      version = "0.0.4"
+    }
+  }
+}
+
+provider "shopify" {
+  store_domain      = "` + shopifytest.StoreDomain + `"
+  store_api_version = "` + shopifytest.StoreApiVersion + `"
+}
+`,
+		"functions.tf": `data "shopify_function" "product_discount" {
+  title     = "product-discount"
+  app_title = "tf-testing"
+  api_type  = "product_discounts"
+}
+
+data "shopify_function" "payment_customization" {
+  title     = "payment-customization"
+  app_title = "tf-testing"
+  api_type  = "payment_customization"
+}
+
+data "shopify_function" "delivery_customization" {
+  title     = "delivery-customization"
+  app_title = "tf-testing"
+  api_type  = "delivery_customization"
+}
+`,
+		"discounts.tf": `resource "shopify_discount" "spring_sale" {
+  function_id = data.shopify_function.product_discount.id
+  title       = "Spring sale"
+  starts_at   = "2024-01-01T00:00:00Z"
+  ends_at     = "2024-02-01T00:00:00Z"
+  combines_with = {
+    order_discounts    = false
+    product_discounts  = false
+    shipping_discounts = true
+  }
+}
+`,
+		"payments.tf": `resource "shopify_payment" "hide_cod" {
+  function_id = data.shopify_function.payment_customization.id
+  title       = "Hide COD"
+  enabled     = true
+}
+
+resource "shopify_payment" "hide_cod_2" {
+  function_id = data.shopify_function.payment_customization.id
+  title       = "Hide COD"
+  enabled     = false
+}
+
+resource "shopify_payment" "payment_1_click" {
+  function_id = "5b1f2a4e-8c1d-4c3e-9f0a-2d6b7e8f9a0b"
+  title       = "1 click"
+  enabled     = false
+}
+`,
+		"deliveries.tf": `resource "shopify_delivery" "rename_express" {
+  function_id = data.shopify_function.delivery_customization.id
+  title       = "Rename express"
+  enabled     = true
+}
+`,
+		"pubsub_webhooks.tf": `resource "shopify_pubsub_webhook" "orders_create_orders" {
+  topic          = "ORDERS_CREATE"
+  format         = "JSON"
+  pubsub_project = "project"
+  pubsub_topic   = "orders"
+}
+`,
+		"imports.tf": files["imports.tf"],
+	}, files)
+
+	assert.Contains(t, files["imports.tf"], `import {
+  to = shopify_pubsub_webhook.orders_create_orders
+  id = "`+pubsub+`"
+}
+`)
+	assert.Contains(t, files["imports.tf"], `import {
+  to = shopify_payment.hide_cod_2
+  id = "gid://shopify/PaymentCustomization/`)
+}
+
+func TestExport_Empty(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	result, err := Export(context.Background(), newTestClient(server), shopifytest.StoreDomain, "0.0.4")
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Skipped)
+	assert.Equal(t, []string{"provider.tf"}, mapKeys(result.Files))
+}
+
+func TestResult_Write(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shopify")
+	result := &Result{Files: map[string][]byte{
+		"provider.tf": []byte("provider \"shopify\" {}\n"),
+		"imports.tf":  []byte("import {}\n"),
+	}}
+
+	require.NoError(t, result.Write(dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, "imports.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "import {}\n", string(content))
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "imports.tf")))
+
+	err = result.Write(dir)
+	assert.ErrorContains(t, err, "provider.tf already exists")
+
+	_, err = os.Stat(filepath.Join(dir, "imports.tf"))
+	assert.True(t, os.IsNotExist(err), "wrote imports.tf before failing")
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
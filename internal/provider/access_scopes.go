@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+// resourceAccessScopes are the access scopes the app needs to manage each
+// function-backed resource.
+var resourceAccessScopes = map[string][]string{
+	"shopify_payment":  {"write_payment_customizations"},
+	"shopify_delivery": {"write_delivery_customizations"},
+	"shopify_discount": {"write_discounts"},
+}
+
+// checkAccessScopes adds an error to diags listing the scopes in required
+// that the app installation was not granted. The installation is read the
+// first time a resource plans and cached on the client; if reading it fails,
+// checkAccessScopes only warns and leaves the check to apply.
+func checkAccessScopes(
+	ctx context.Context,
+	client *shopify.ShopifyAdminClinetImpl,
+	typeName string,
+	required []string,
+	diags *diag.Diagnostics,
+) {
+	if client == nil || len(required) == 0 {
+		return
+	}
+
+	installation, err := client.CurrentAppInstallation(ctx)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Verify Shopify Access Scopes",
+			fmt.Sprintf("Reading the app installation's access scopes failed, so %s's are not checked before apply: %s", typeName, err),
+		)
+		return
+	}
+
+	missing := installation.MissingAccessScopes(required...)
+	if len(missing) == 0 {
+		return
+	}
+
+	diags.AddError(
+		"Missing Shopify Access Scopes",
+		fmt.Sprintf(
+			"%s needs access scopes the app was not granted: %s. Granted scopes: %s. "+
+				"Add the missing scopes to the app's configuration and have the store approve them.",
+			typeName,
+			strings.Join(missing, ", "),
+			strings.Join(installation.AccessScopes, ", "),
+		),
+	)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAccessScopes(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	server.SetAccessScopes("write_discounts")
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	checkAccessScopes(ctx, client, "shopify_discount", resourceAccessScopes["shopify_discount"], &diags)
+	checkAccessScopes(ctx, nil, "shopify_payment", resourceAccessScopes["shopify_payment"], &diags)
+	assert.Empty(t, diags)
+
+	checkAccessScopes(ctx, client, "shopify_payment", resourceAccessScopes["shopify_payment"], &diags)
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Missing Shopify Access Scopes", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "shopify_payment needs access scopes the app was not granted: write_payment_customizations.")
+	assert.Contains(t, diags[0].Detail(), "Granted scopes: write_discounts.")
+}
+
+func TestCheckAccessScopes_InstallationUnavailable(t *testing.T) {
+	server := shopifytest.NewServer()
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+	server.Close()
+
+	var diags diag.Diagnostics
+	checkAccessScopes(context.Background(), client, "shopify_payment", resourceAccessScopes["shopify_payment"], &diags)
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+	assert.Equal(t, "Unable to Verify Shopify Access Scopes", diags[0].Summary())
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ datasource.DataSource = (*appInstallationDataSource)(nil)
+
+type appInstallationDataSource struct {
+	client *shopify.ShopifyAdminClinetImpl
+}
+
+type appInstallationDataSourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	AppTitle     types.String   `tfsdk:"app_title"`
+	AccessScopes []types.String `tfsdk:"access_scopes"`
+}
+
+func NewAppInstallationDataSource() datasource.DataSource {
+	return &appInstallationDataSource{}
+}
+
+func (d *appInstallationDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_app_installation"
+}
+
+func (d *appInstallationDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "The installation of the app the provider authenticates as",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The app installation GID",
+				Computed:    true,
+			},
+			"app_title": schema.StringAttribute{
+				Description: "The title of the installed app",
+				Computed:    true,
+			},
+			"access_scopes": schema.ListAttribute{
+				Description: "The access scopes granted to the app, e.g. write_discounts",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *appInstallationDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*shopify.ShopifyAdminClinetImpl)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *shopify.ShopifyAdminClinetImpl, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = c
+}
+
+func (d *appInstallationDataSource) Read(
+	ctx context.Context,
+	_ datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_app_installation")
+	defer addDeprecationWarnings(d.client, "shopify_app_installation", &resp.Diagnostics)
+
+	installation, err := d.client.CurrentAppInstallation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get shopify app installation", err.Error())
+		return
+	}
+
+	data := appInstallationDataSourceModel{
+		ID:           types.StringValue(installation.ID),
+		AppTitle:     types.StringValue(installation.AppTitle),
+		AccessScopes: []types.String{},
+	}
+
+	for _, scope := range installation.AccessScopes {
+		data.AccessScopes = append(data.AccessScopes, types.StringValue(scope))
+	}
+
+	tflog.Trace(ctx, "read a shopify app installation data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAppInstallationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "shopify_app_installation" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.shopify_app_installation.test", "id"),
+					resource.TestCheckResourceAttrSet("data.shopify_app_installation.test", "app_title"),
+					resource.TestCheckTypeSetElemAttr("data.shopify_app_installation.test", "access_scopes.*", "write_discounts"),
+				),
+			},
+		},
+	})
+}
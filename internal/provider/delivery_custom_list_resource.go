@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ list.ListResource = (*deliveryCustomListResource)(nil)
+var _ list.ListResourceWithConfigure = (*deliveryCustomListResource)(nil)
+
+type deliveryCustomListResource struct {
+	client *shopify.ShopifyAdminClinetImpl
+}
+
+type deliveryCustomListResourceModel struct {
+	FunctionID types.String `tfsdk:"function_id"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+}
+
+func NewDeliveryCustomListResource() list.ListResource {
+	return &deliveryCustomListResource{}
+}
+
+func (r *deliveryCustomListResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_delivery"
+}
+
+func (r *deliveryCustomListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the store's delivery customizations",
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Description: "Only list delivery customizations backed by this function",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+						"Must be a valid UUID",
+					),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only list enabled, or only disabled, delivery customizations",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *deliveryCustomListResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*shopify.ShopifyAdminClinetImpl)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *shopify.ShopifyAdminClinetImpl, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = c
+}
+
+func (r *deliveryCustomListResource) List(
+	ctx context.Context,
+	req list.ListRequest,
+	stream *list.ListResultsStream,
+) {
+	var config deliveryCustomListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	deliveries, err := r.client.Delivery.List(ctx, customizationFilter(config.FunctionID, config.Enabled))
+	if err != nil {
+		diags.AddError("Failed to list shopify delivery customizations", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	streamListResults(ctx, req, stream, deliveries,
+		func(d shopify.DeliveryNode) string { return d.ID },
+		func(d shopify.DeliveryNode) string { return d.Title },
+		func(d shopify.DeliveryNode) any {
+			return deliveryCustomResourceModel{
+				FunctionID:     types.StringValue(d.FunctionID),
+				FunctionHandle: types.StringNull(),
+				ID:             types.StringValue(d.ID),
+				Title:          types.StringValue(d.Title),
+				Enabled:        types.BoolValue(d.Enabled),
+				Timeouts:       nullTimeouts(),
+			}
+		},
+	)
+}
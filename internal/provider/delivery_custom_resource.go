@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,12 +18,26 @@ import (
 )
 
 var _ resource.Resource = (*deliveryCustomResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*deliveryCustomResource)(nil)
+var _ resource.ResourceWithUpgradeState = (*deliveryCustomResource)(nil)
+var _ resource.ResourceWithMoveState = (*deliveryCustomResource)(nil)
+var _ resource.ResourceWithIdentity = (*deliveryCustomResource)(nil)
 
 type deliveryCustomResource struct {
 	client *shopify.ShopifyAdminClinetImpl
 }
 
 type deliveryCustomResourceModel struct {
+	FunctionID     types.String   `tfsdk:"function_id"`
+	FunctionHandle types.String   `tfsdk:"function_handle"`
+	ID             types.String   `tfsdk:"id"`
+	Title          types.String   `tfsdk:"title"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// deliveryCustomResourceModelV0 is state at schema version 0.
+type deliveryCustomResourceModelV0 struct {
 	FunctionID types.String `tfsdk:"function_id"`
 	ID         types.String `tfsdk:"id"`
 	Title      types.String `tfsdk:"title"`
@@ -43,12 +57,13 @@ func (r *deliveryCustomResource) Metadata(
 }
 
 func (r *deliveryCustomResource) Schema(
-	_ context.Context,
+	ctx context.Context,
 	_ resource.SchemaRequest,
 	resp *resource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
 		Description: "Shopify Function Delivery Customization Resource",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -57,8 +72,11 @@ func (r *deliveryCustomResource) Schema(
 				},
 			},
 			"function_id": schema.StringAttribute{
-				Required: true,
+				Description: "The ID of a function that implements the delivery_customization Function API. Set this or function_handle",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -66,6 +84,14 @@ func (r *deliveryCustomResource) Schema(
 						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
 						"Must be a valid UUID",
 					),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("function_handle")),
+				},
+			},
+			"function_handle": schema.StringAttribute{
+				Description: "The handle of the function, as set in its shopify.extension.toml. Unlike function_id, it is the same on every store the app is installed on",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"title": schema.StringAttribute{
@@ -78,6 +104,70 @@ func (r *deliveryCustomResource) Schema(
 				Required: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *deliveryCustomResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("DeliveryCustomization")
+}
+
+func (r *deliveryCustomResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// State written before the timeouts block and function_handle.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"function_id": schema.StringAttribute{
+						Required: true,
+					},
+					"title": schema.StringAttribute{
+						Required: true,
+					},
+					"enabled": schema.BoolAttribute{
+						Required: true,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior deliveryCustomResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := deliveryCustomResourceModel{
+					FunctionID:     prior.FunctionID,
+					FunctionHandle: types.StringNull(),
+					ID:             prior.ID,
+					Title:          prior.Title,
+					Enabled:        prior.Enabled,
+					Timeouts:       nullTimeouts(),
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+func (r *deliveryCustomResource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		compatibleStateMover(r, "shopify_delivery", "DeliveryCustomization"),
 	}
 }
 
@@ -106,20 +196,43 @@ func (r *deliveryCustomResource) Configure(
 	r.client = c
 }
 
+func (r *deliveryCustomResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	checkAccessScopes(ctx, r.client, "shopify_delivery", resourceAccessScopes["shopify_delivery"], &resp.Diagnostics)
+	planFunctionHandle(ctx, r.client, req, resp)
+	checkFunctionAPIType(ctx, r.client, resp.Plan, "shopify_delivery", &resp.Diagnostics)
+}
+
 func (r *deliveryCustomResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_delivery")
+	defer addDeprecationWarnings(r.client, "shopify_delivery", &resp.Diagnostics)
+
 	var data deliveryCustomResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_delivery", "create", createTimeout)
+	defer cancel()
+
 	dn := &shopify.DeliveryNode{
-		Title:   data.Title.ValueString(),
-		Enabled: data.Enabled.ValueBool(),
+		FunctionHandle: data.FunctionHandle.ValueString(),
+		Title:          data.Title.ValueString(),
+		Enabled:        data.Enabled.ValueBool(),
 	}
 
 	q, err := r.client.Delivery.Create(ctx, data.FunctionID.ValueString(), dn)
@@ -128,12 +241,16 @@ func (r *deliveryCustomResource) Create(
 		return
 	}
 
-	data.FunctionID = types.StringValue(data.FunctionID.ValueString())
+	if q.FunctionID != "" {
+		data.FunctionID = types.StringValue(q.FunctionID)
+	}
+
 	data.ID = types.StringValue(q.ID)
 	data.Title = types.StringValue(q.Title)
 	data.Enabled = types.BoolValue(q.Enabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *deliveryCustomResource) Read(
@@ -141,8 +258,25 @@ func (r *deliveryCustomResource) Read(
 	req resource.ReadRequest,
 	resp *resource.ReadResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_delivery")
+	defer addDeprecationWarnings(r.client, "shopify_delivery", &resp.Diagnostics)
+
 	var data deliveryCustomResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_delivery", "read", readTimeout)
+	defer cancel()
 
 	q, err := r.client.Delivery.Get(ctx, data.ID.ValueString())
 	if err != nil {
@@ -157,6 +291,10 @@ func (r *deliveryCustomResource) Read(
 	data.Title = types.StringValue(q.Title)
 	data.Enabled = types.BoolValue(q.Enabled)
 
+	if q.FunctionID != "" {
+		data.FunctionID = types.StringValue(q.FunctionID)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -165,12 +303,24 @@ func (r *deliveryCustomResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_delivery")
+	defer addDeprecationWarnings(r.client, "shopify_delivery", &resp.Diagnostics)
+
 	var data deliveryCustomResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_delivery", "update", updateTimeout)
+	defer cancel()
+
 	dn := &shopify.DeliveryNode{
 		ID:      data.ID.ValueString(),
 		Title:   data.Title.ValueString(),
@@ -188,6 +338,7 @@ func (r *deliveryCustomResource) Update(
 	data.Enabled = types.BoolValue(q.Enabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *deliveryCustomResource) Delete(
@@ -195,13 +346,24 @@ func (r *deliveryCustomResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_delivery")
+	defer addDeprecationWarnings(r.client, "shopify_delivery", &resp.Diagnostics)
+
 	var data deliveryCustomResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, "shopify_delivery", "delete", deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.Delivery.Delete(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Delete Shopify Delivery Custom Failed", err.Error())
@@ -214,16 +376,5 @@ func (r *deliveryCustomResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	idParts := strings.Split(req.ID, ",")
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Invalid Import Format",
-			"Please use the format 'id,function_id' to import resources",
-		)
-
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("function_id"), idParts[1])...)
+	importID(ctx, "DeliveryCustomization", req, resp)
 }
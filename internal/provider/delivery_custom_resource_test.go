@@ -5,12 +5,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccDeliveryCustomResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDeliveryCustomResourceConfig("create-test", true),
@@ -32,7 +31,6 @@ func TestAccDeliveryCustomResource(t *testing.T) {
 				ResourceName:      "shopify_delivery.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				ImportStateIdFunc: testAccDeliveryCustomResourceImportStateIdFunc,
 			},
 		},
 	})
@@ -51,12 +49,3 @@ func testAccDeliveryCustomResourceConfig(title string, enabled bool) string {
 		enabled,
 	)
 }
-
-func testAccDeliveryCustomResourceImportStateIdFunc(s *terraform.State) (string, error) {
-	rs, ok := s.RootModule().Resources["shopify_delivery.test"]
-	if !ok {
-		return "", fmt.Errorf("Resource not found: shopify_delivery.test")
-	}
-
-	return fmt.Sprintf("%s,%s", rs.Primary.ID, rs.Primary.Attributes["function_id"]), nil
-}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+// addDeprecationWarnings reports, as a single warning, the Admin API
+// deprecations typeName's operations ran into that have not been reported
+// yet. Operations must run with a context scoped to typeName by
+// shopify.WithDeprecationScope.
+func addDeprecationWarnings(
+	client *shopify.ShopifyAdminClinetImpl,
+	typeName string,
+	diags *diag.Diagnostics,
+) {
+	if client == nil {
+		return
+	}
+
+	deprecations := client.TakeDeprecations(typeName)
+	if len(deprecations) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, d := range deprecations {
+		lines = append(lines, fmt.Sprintf("  - %s: %s", d.Operation, d.Reason))
+	}
+
+	diags.AddWarning(
+		"Deprecated Shopify API Usage",
+		fmt.Sprintf(
+			"%s uses parts of the Shopify Admin API that are deprecated in API version %s:\n\n%s\n\n"+
+				"They will stop working once Shopify sunsets them. Upgrade the provider or move to a newer store_api_version before then.",
+			typeName,
+			client.ApiVersion(),
+			strings.Join(lines, "\n"),
+		),
+	)
+}
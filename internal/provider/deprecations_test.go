@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddDeprecationWarnings(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	server.Deprecate("shopifyFunctions", "Use functions instead.")
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx := shopify.WithDeprecationScope(context.Background(), "shopify_function")
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Function.List(ctx)
+		require.NoError(t, err)
+	}
+
+	var diags diag.Diagnostics
+	addDeprecationWarnings(client, "shopify_function", &diags)
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Deprecated Shopify API Usage", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "shopifyFunctions: Use functions instead.")
+	assert.Contains(t, diags[0].Detail(), shopifytest.StoreApiVersion)
+
+	diags = nil
+	addDeprecationWarnings(client, "shopify_function", &diags)
+	addDeprecationWarnings(client, "shopify_discount", &diags)
+	addDeprecationWarnings(nil, "shopify_discount", &diags)
+
+	assert.Empty(t, diags)
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ list.ListResource = (*discountAutomaticListResource)(nil)
+var _ list.ListResourceWithConfigure = (*discountAutomaticListResource)(nil)
+
+type discountAutomaticListResource struct {
+	client *shopify.ShopifyAdminClinetImpl
+}
+
+type discountAutomaticListResourceModel struct {
+	FunctionID types.String `tfsdk:"function_id"`
+	Status     types.String `tfsdk:"status"`
+}
+
+func NewDiscountAutomaticListResource() list.ListResource {
+	return &discountAutomaticListResource{}
+}
+
+func (r *discountAutomaticListResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_discount"
+}
+
+func (r *discountAutomaticListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the store's automatic app discounts",
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Description: "Only list discounts backed by this function",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+						"Must be a valid UUID",
+					),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Only list discounts with this status: ACTIVE, EXPIRED or SCHEDULED",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ACTIVE", "EXPIRED", "SCHEDULED"),
+				},
+			},
+		},
+	}
+}
+
+func (r *discountAutomaticListResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*shopify.ShopifyAdminClinetImpl)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *shopify.ShopifyAdminClinetImpl, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = c
+}
+
+func (r *discountAutomaticListResource) List(
+	ctx context.Context,
+	req list.ListRequest,
+	stream *list.ListResultsStream,
+) {
+	var config discountAutomaticListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	discounts, err := r.client.Discount.List(ctx, &shopify.DiscountFilter{
+		Status:     config.Status.ValueString(),
+		FunctionID: config.FunctionID.ValueString(),
+	})
+	if err != nil {
+		diags.AddError("Failed to list shopify discounts", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	streamListResults(ctx, req, stream, discounts,
+		func(d shopify.DiscountNode) string { return d.ID },
+		func(d shopify.DiscountNode) string { return d.Title },
+		func(d shopify.DiscountNode) any {
+			endsAt := types.StringNull()
+			if d.EndsAt != "" {
+				endsAt = types.StringValue(d.EndsAt)
+			}
+
+			return discountAutomaticResourceModel{
+				FunctionID:     types.StringValue(d.FunctionID),
+				FunctionHandle: types.StringNull(),
+				ID:             types.StringValue(d.ID),
+				Title:          types.StringValue(d.Title),
+				StartsAt:       types.StringValue(d.StartsAt),
+				EndsAt:         endsAt,
+				CombinesWith: &discountAutomaticCombinesWithResourceModel{
+					OrderDiscounts:    types.BoolValue(d.CombinesWith.OrderDiscounts),
+					ProductDiscounts:  types.BoolValue(d.CombinesWith.ProductDiscounts),
+					ShippingDiscounts: types.BoolValue(d.CombinesWith.ShippingDiscounts),
+				},
+				Timeouts: nullTimeouts(),
+			}
+		},
+	)
+}
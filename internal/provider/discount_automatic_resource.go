@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,12 +18,28 @@ import (
 )
 
 var _ resource.Resource = (*discountAutomaticResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*discountAutomaticResource)(nil)
+var _ resource.ResourceWithUpgradeState = (*discountAutomaticResource)(nil)
+var _ resource.ResourceWithMoveState = (*discountAutomaticResource)(nil)
+var _ resource.ResourceWithIdentity = (*discountAutomaticResource)(nil)
 
 type discountAutomaticResource struct {
 	client *shopify.ShopifyAdminClinetImpl
 }
 
 type discountAutomaticResourceModel struct {
+	FunctionID     types.String                                `tfsdk:"function_id"`
+	FunctionHandle types.String                                `tfsdk:"function_handle"`
+	ID             types.String                                `tfsdk:"id"`
+	Title          types.String                                `tfsdk:"title"`
+	StartsAt       types.String                                `tfsdk:"starts_at"`
+	EndsAt         types.String                                `tfsdk:"ends_at"`
+	CombinesWith   *discountAutomaticCombinesWithResourceModel `tfsdk:"combines_with"`
+	Timeouts       timeouts.Value                              `tfsdk:"timeouts"`
+}
+
+// discountAutomaticResourceModelV0 is state at schema version 0.
+type discountAutomaticResourceModelV0 struct {
 	FunctionID   types.String                                `tfsdk:"function_id"`
 	ID           types.String                                `tfsdk:"id"`
 	Title        types.String                                `tfsdk:"title"`
@@ -57,6 +73,7 @@ func (r *discountAutomaticResource) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		Description: "Shopify Function Discount Automatic Resource",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -65,8 +82,11 @@ func (r *discountAutomaticResource) Schema(
 				},
 			},
 			"function_id": schema.StringAttribute{
-				Required: true,
+				Description: "The ID of a function that implements the discount, product_discounts, order_discounts or shipping_discounts Function API. Set this or function_handle",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -74,6 +94,14 @@ func (r *discountAutomaticResource) Schema(
 						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
 						"Must be a valid UUID",
 					),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("function_handle")),
+				},
+			},
+			"function_handle": schema.StringAttribute{
+				Description: "The handle of the function, as set in its shopify.extension.toml. Unlike function_id, it is the same on every store the app is installed on",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"title": schema.StringAttribute{
@@ -115,6 +143,89 @@ func (r *discountAutomaticResource) Schema(
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *discountAutomaticResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("DiscountAutomaticNode")
+}
+
+func (r *discountAutomaticResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// State written before the timeouts block and function_handle.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"function_id": schema.StringAttribute{
+						Required: true,
+					},
+					"title": schema.StringAttribute{
+						Required: true,
+					},
+					"starts_at": schema.StringAttribute{
+						Required: true,
+					},
+					"ends_at": schema.StringAttribute{
+						Optional: true,
+					},
+					"combines_with": schema.SingleNestedAttribute{
+						Required: true,
+						Attributes: map[string]schema.Attribute{
+							"order_discounts": schema.BoolAttribute{
+								Required: true,
+							},
+							"product_discounts": schema.BoolAttribute{
+								Required: true,
+							},
+							"shipping_discounts": schema.BoolAttribute{
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior discountAutomaticResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := discountAutomaticResourceModel{
+					FunctionID:     prior.FunctionID,
+					FunctionHandle: types.StringNull(),
+					ID:             prior.ID,
+					Title:          prior.Title,
+					StartsAt:       prior.StartsAt,
+					EndsAt:         prior.EndsAt,
+					CombinesWith:   prior.CombinesWith,
+					Timeouts:       nullTimeouts(),
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+func (r *discountAutomaticResource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		compatibleStateMover(r, "shopify_discount", "DiscountAutomaticNode"),
 	}
 }
 
@@ -143,20 +254,43 @@ func (r *discountAutomaticResource) Configure(
 	r.client = c
 }
 
+func (r *discountAutomaticResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	checkAccessScopes(ctx, r.client, "shopify_discount", resourceAccessScopes["shopify_discount"], &resp.Diagnostics)
+	planFunctionHandle(ctx, r.client, req, resp)
+	checkFunctionAPIType(ctx, r.client, resp.Plan, "shopify_discount", &resp.Diagnostics)
+}
+
 func (r *discountAutomaticResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_discount")
+	defer addDeprecationWarnings(r.client, "shopify_discount", &resp.Diagnostics)
+
 	var data discountAutomaticResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_discount", "create", createTimeout)
+	defer cancel()
+
 	dn := &shopify.DiscountNode{
-		Title:    data.Title.ValueString(),
-		StartsAt: data.StartsAt.ValueString(),
+		FunctionHandle: data.FunctionHandle.ValueString(),
+		Title:          data.Title.ValueString(),
+		StartsAt:       data.StartsAt.ValueString(),
 		CombinesWith: &shopify.DiscountCombinesWith{
 			OrderDiscounts:    data.CombinesWith.OrderDiscounts.ValueBool(),
 			ProductDiscounts:  data.CombinesWith.ProductDiscounts.ValueBool(),
@@ -170,11 +304,14 @@ func (r *discountAutomaticResource) Create(
 
 	q, err := r.client.Discount.Create(ctx, data.FunctionID.ValueString(), dn)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create shopify discount automatic", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to create shopify discount automatic", err)
 		return
 	}
 
-	data.FunctionID = types.StringValue(data.FunctionID.ValueString())
+	if q.FunctionID != "" {
+		data.FunctionID = types.StringValue(q.FunctionID)
+	}
+
 	data.ID = types.StringValue(q.ID)
 	data.Title = types.StringValue(q.Title)
 	data.StartsAt = types.StringValue(q.StartsAt)
@@ -191,6 +328,7 @@ func (r *discountAutomaticResource) Create(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *discountAutomaticResource) Read(
@@ -198,12 +336,29 @@ func (r *discountAutomaticResource) Read(
 	req resource.ReadRequest,
 	resp *resource.ReadResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_discount")
+	defer addDeprecationWarnings(r.client, "shopify_discount", &resp.Diagnostics)
+
 	var data discountAutomaticResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_discount", "read", readTimeout)
+	defer cancel()
 
 	q, err := r.client.Discount.Get(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get shopify discount automatic", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to get shopify discount automatic", err)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -225,6 +380,10 @@ func (r *discountAutomaticResource) Read(
 		data.EndsAt = types.StringNull()
 	}
 
+	if q.FunctionID != "" {
+		data.FunctionID = types.StringValue(q.FunctionID)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -233,12 +392,24 @@ func (r *discountAutomaticResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_discount")
+	defer addDeprecationWarnings(r.client, "shopify_discount", &resp.Diagnostics)
+
 	var data discountAutomaticResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_discount", "update", updateTimeout)
+	defer cancel()
+
 	dn := &shopify.DiscountNode{
 		ID:       data.ID.ValueString(),
 		Title:    data.Title.ValueString(),
@@ -256,7 +427,7 @@ func (r *discountAutomaticResource) Update(
 
 	q, err := r.client.Discount.Update(ctx, dn)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update shopify discount automatic", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to update shopify discount automatic", err)
 		return
 	}
 
@@ -276,6 +447,7 @@ func (r *discountAutomaticResource) Update(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *discountAutomaticResource) Delete(
@@ -283,16 +455,27 @@ func (r *discountAutomaticResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_discount")
+	defer addDeprecationWarnings(r.client, "shopify_discount", &resp.Diagnostics)
+
 	var data discountAutomaticResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, "shopify_discount", "delete", deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.Discount.Delete(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete shopify discount automatic", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to delete shopify discount automatic", err)
 		return
 	}
 }
@@ -302,16 +485,5 @@ func (r *discountAutomaticResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	idParts := strings.Split(req.ID, ",")
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Invalid Import Format",
-			"Please use the format 'id,function_id' to import the resource",
-		)
-
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("function_id"), idParts[1])...)
+	importID(ctx, "DiscountAutomaticNode", req, resp)
 }
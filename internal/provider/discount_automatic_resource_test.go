@@ -3,19 +3,19 @@ package provider
 import (
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccDiscountAutomaticResource(t *testing.T) {
-	startTime := time.Now().UTC().Format("2006-01-02T15:04:05Z")
-	endTime := time.Now().UTC().Add(24 * time.Hour).Format("2006-01-02T15:04:05Z")
-	updatedStartTime := time.Now().UTC().Add(1 * time.Hour).Format("2006-01-02T15:04:05Z")
+	// Fixed times keep the requests identical between recording and replaying
+	// a cassette.
+	startTime := "2024-01-01T00:00:00Z"
+	endTime := "2024-01-02T00:00:00Z"
+	updatedStartTime := "2024-01-01T01:00:00Z"
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDiscountAutomaticResourceConfig(startTime, "", true, false, true, false),
@@ -44,7 +44,6 @@ func TestAccDiscountAutomaticResource(t *testing.T) {
 				ResourceName:      "shopify_discount.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				ImportStateIdFunc: testAccDiscountAutomaticResourceImportStateIdFunc,
 			},
 		},
 	})
@@ -90,12 +89,3 @@ func testAccDiscountAutomaticResourceConfig(
 		shippingDiscounts,
 	)
 }
-
-func testAccDiscountAutomaticResourceImportStateIdFunc(s *terraform.State) (string, error) {
-	rs, ok := s.RootModule().Resources["shopify_discount.test"]
-	if !ok {
-		return "", fmt.Errorf("Resource not found: shopify_discount.test")
-	}
-
-	return fmt.Sprintf("%s,%s", rs.Primary.ID, rs.Primary.Attributes["function_id"]), nil
-}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+// resourceFunctionAPITypes are the Function APIs a function can implement to
+// back each function-backed resource. Automatic app discounts take the unified
+// Discount Function API and the product, order and shipping discount APIs it
+// replaces.
+var resourceFunctionAPITypes = map[string][]string{
+	"shopify_payment":  {"payment_customization"},
+	"shopify_delivery": {"delivery_customization"},
+	"shopify_discount": {"discount", "product_discounts", "order_discounts", "shipping_discounts"},
+}
+
+// checkFunctionAPIType adds an error on function_id when the planned function
+// implements a Function API that can't back typeName. Shopify only rejects the
+// mismatch when the resource is applied, and with a vaguer message. It does
+// nothing if the function catalogue can't be listed or doesn't have the
+// function, apply reports those.
+func checkFunctionAPIType(
+	ctx context.Context,
+	client *shopify.ShopifyAdminClinetImpl,
+	plan tfsdk.Plan,
+	typeName string,
+	diags *diag.Diagnostics,
+) {
+	if client == nil || plan.Raw.IsNull() {
+		return
+	}
+
+	var functionID types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("function_id"), &functionID)...)
+	if diags.HasError() || functionID.IsUnknown() || functionID.IsNull() {
+		return
+	}
+
+	functions, err := client.Function.List(ctx)
+	if err != nil {
+		return
+	}
+
+	i := slices.IndexFunc(functions.Nodes, func(node shopify.FunctionNode) bool {
+		return node.ID == functionID.ValueString()
+	})
+
+	apiTypes := resourceFunctionAPITypes[typeName]
+	if i < 0 || slices.Contains(apiTypes, functions.Nodes[i].APIType) {
+		return
+	}
+
+	function := functions.Nodes[i]
+	diags.AddAttributeError(
+		path.Root("function_id"),
+		"Wrong Shopify Function API Type",
+		fmt.Sprintf(
+			"%s needs a function that implements %s, but %q (%s) implements %s.",
+			typeName,
+			strings.Join(apiTypes, " or "),
+			function.Title,
+			function.ID,
+			function.APIType,
+		),
+	)
+}
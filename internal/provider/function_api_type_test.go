@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFunctionAPIType(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		Title:    "payment-customization",
+		APIType:  "payment_customization",
+		AppTitle: "tf-testing",
+	})
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740",
+		Title:    "delivery-customization",
+		APIType:  "delivery_customization",
+		AppTitle: "tf-testing",
+	})
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewPaymentCustomResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// The plan of a resource being destroyed.
+	destroyPlan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	plan := func(functionID string) tfsdk.Plan {
+		plan := destroyPlan
+		diags := plan.SetAttribute(ctx, path.Root("function_id"), functionID)
+		require.False(t, diags.HasError(), diags)
+
+		return plan
+	}
+
+	var diags diag.Diagnostics
+	checkFunctionAPIType(ctx, client, plan("f2e906be-a93a-48c6-a2cc-99c64e5ab816"), "shopify_payment", &diags)
+	checkFunctionAPIType(ctx, client, plan("00000000-0000-0000-0000-000000000000"), "shopify_payment", &diags)
+	checkFunctionAPIType(ctx, client, destroyPlan, "shopify_payment", &diags)
+	assert.Empty(t, diags)
+
+	checkFunctionAPIType(ctx, client, plan("3a2c6a43-6ac1-4d4d-bbd9-59286cc33740"), "shopify_payment", &diags)
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Wrong Shopify Function API Type", diags[0].Summary())
+	assert.Equal(
+		t,
+		`shopify_payment needs a function that implements payment_customization, `+
+			`but "delivery-customization" (3a2c6a43-6ac1-4d4d-bbd9-59286cc33740) implements delivery_customization.`,
+		diags[0].Detail(),
+	)
+
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	require.True(t, ok)
+	assert.Equal(t, path.Root("function_id"), withPath.Path())
+}
+
+func TestCheckFunctionAPIType_Discount(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "7c0f1c3e-5a7f-4c55-9a36-3d2b1c1e8f10",
+		Title:    "discount",
+		APIType:  "discount",
+		AppTitle: "tf-testing",
+	})
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewDiscountAutomaticResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	diags := plan.SetAttribute(ctx, path.Root("function_id"), "7c0f1c3e-5a7f-4c55-9a36-3d2b1c1e8f10")
+	require.False(t, diags.HasError(), diags)
+
+	checkFunctionAPIType(ctx, client, plan, "shopify_discount", &diags)
+	assert.Empty(t, diags)
+}
@@ -21,6 +21,7 @@ type FunctinDataSource struct {
 
 type FunctionDataSourceModel struct {
 	ID      types.String `tfsdk:"id"`
+	Handle  types.String `tfsdk:"handle"`
 	Title   types.String `tfsdk:"title"`
 	APIType types.String `tfsdk:"api_type"`
 	APPName types.String `tfsdk:"app_title"`
@@ -49,6 +50,10 @@ func (d *FunctinDataSource) Schema(
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"handle": schema.StringAttribute{
+				Description: "The function handle. Only set on API versions that support function handles (2025-04 and later).",
+				Computed:    true,
+			},
 			"title": schema.StringAttribute{
 				Required: true,
 				Validators: []validator.String{
@@ -101,6 +106,9 @@ func (d *FunctinDataSource) Read(
 	req datasource.ReadRequest,
 	resp *datasource.ReadResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_function")
+	defer addDeprecationWarnings(d.client, "shopify_function", &resp.Diagnostics)
+
 	var data FunctionDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -116,6 +124,7 @@ func (d *FunctinDataSource) Read(
 	for _, node := range q.Nodes {
 		if node.APPName == data.APPName.ValueString() && node.Title == data.Title.ValueString() {
 			data.ID = types.StringValue(node.ID)
+			data.Handle = stringValueOrNull(node.Handle)
 			data.APIType = types.StringValue(node.APIType)
 			break
 		}
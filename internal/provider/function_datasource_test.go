@@ -9,7 +9,7 @@ import (
 
 func TestAccFunctionDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			// 讀取數據源測試
 			{
@@ -34,9 +34,37 @@ func testAccFunctionDataSourceConfig() string {
 	`
 }
 
+func TestAccFunctionDataSource_CacheDisabled(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "shopify" {
+						cache_functions = false
+					}
+
+					data "shopify_function" "first" {
+						title     = "product-discount"
+						app_title = "tf-testing"
+					}
+
+					data "shopify_function" "second" {
+						title     = "product-discount"
+						app_title = "tf-testing"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.shopify_function.first", "id", "data.shopify_function.second", "id"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccFunctionDataSource_NotFound(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccFunctionDataSourceConfig_NotFound(),
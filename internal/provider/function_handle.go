@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+// planFunctionHandle plans function_id for a resource configured with
+// function_handle, by looking the handle up in the function catalogue. A
+// handle that now names another function replaces the resource, as changing
+// function_id does.
+//
+// A handle that isn't in the catalogue is an error: leaving function_id to be
+// known after apply would plan a replacement on every run. Versions before
+// functionHandle neither accept nor list function handles: the handle is
+// matched against the function titles it is generated from, and the resource
+// is created with the function_id it resolves to.
+func planFunctionHandle(
+	ctx context.Context,
+	client *shopify.ShopifyAdminClinetImpl,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var functionHandle types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("function_handle"), &functionHandle)...)
+	if resp.Diagnostics.HasError() || functionHandle.IsNull() {
+		return
+	}
+
+	if functionHandle.IsUnknown() {
+		planFunctionID(ctx, req, resp, types.StringUnknown())
+		return
+	}
+
+	if client == nil {
+		return
+	}
+
+	functions, err := client.Function.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get shopify function", err.Error())
+		return
+	}
+
+	nodes := functions.WithHandle(client.ApiVersion(), functionHandle.ValueString())
+
+	if client.Supports(shopify.FeatureFunctionHandle) {
+		if len(nodes) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("function_handle"),
+				"Function Handle Not Found",
+				fmt.Sprintf(
+					"No function in the store has the handle %q. Deploy the app that provides it, "+
+						"or check the handles the shopify_function data source lists.",
+					functionHandle.ValueString(),
+				),
+			)
+			return
+		}
+
+		planFunctionID(ctx, req, resp, types.StringValue(nodes[0].ID))
+		return
+	}
+
+	switch len(nodes) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("function_handle"),
+			"Function Handle Not Found",
+			fmt.Sprintf(
+				"No function's title matches the handle %q. Shopify API version %s does not list function handles, "+
+					"so function_handle is matched against the titles handles are generated from. "+
+					"Reference the function by function_id, or set store_api_version to %s or later.",
+				functionHandle.ValueString(),
+				client.ApiVersion(),
+				shopify.FeatureVersion(shopify.FeatureFunctionHandle),
+			),
+		)
+	case 1:
+		planFunctionID(ctx, req, resp, types.StringValue(nodes[0].ID))
+	default:
+		ids := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			ids = append(ids, node.ID)
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("function_handle"),
+			"Ambiguous Function Handle",
+			fmt.Sprintf(
+				"The titles of functions %s all match the handle %q. Shopify API version %s does not list function handles, "+
+					"so they can't be told apart. Reference the function by function_id, or set store_api_version to %s or later.",
+				strings.Join(ids, ", "),
+				functionHandle.ValueString(),
+				client.ApiVersion(),
+				shopify.FeatureVersion(shopify.FeatureFunctionHandle),
+			),
+		)
+	}
+}
+
+// planFunctionID sets the planned function_id to functionID, and replaces
+// the resource if that may differ from the function it was created with.
+func planFunctionID(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+	functionID types.String,
+) {
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("function_id"), functionID)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var prior types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("function_id"), &prior)...)
+	if resp.Diagnostics.HasError() || prior.Equal(functionID) {
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("function_id"))
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanFunctionHandle(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		Handle:   "payment-customization",
+		Title:    "payment-customization",
+		APIType:  "payment_customization",
+		AppTitle: "tf-testing",
+	})
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewPaymentCustomResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	// modifyPlan plans function_handle over state, which is null for a new
+	// resource and otherwise holds functionID.
+	modifyPlan := func(apiVersion string, functionHandle string, functionID *string) *resource.ModifyPlanResponse {
+		client := shopify.New(
+			shopifytest.StoreDomain,
+			shopifytest.StoreAccessToken,
+			apiVersion,
+			shopify.WithHTTPClient(server.Client()),
+		)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: null}
+		require.False(t, plan.SetAttribute(ctx, path.Root("function_handle"), functionHandle).HasError())
+		require.False(t, plan.SetAttribute(ctx, path.Root("function_id"), types.StringUnknown()).HasError())
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: null}
+		if functionID != nil {
+			require.False(t, state.SetAttribute(ctx, path.Root("function_id"), *functionID).HasError())
+		}
+
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		planFunctionHandle(ctx, client, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+
+		return resp
+	}
+
+	plannedFunctionID := func(resp *resource.ModifyPlanResponse) types.String {
+		var functionID types.String
+		require.False(t, resp.Plan.GetAttribute(ctx, path.Root("function_id"), &functionID).HasError())
+
+		return functionID
+	}
+
+	resp := modifyPlan("2025-04", "payment-customization", nil)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Equal(t, types.StringValue("f2e906be-a93a-48c6-a2cc-99c64e5ab816"), plannedFunctionID(resp))
+
+	resp = modifyPlan("2025-04", "missing", nil)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Function Handle Not Found", resp.Diagnostics[0].Summary())
+	assert.Equal(t, path.Root("function_handle"), resp.Diagnostics[0].(diag.DiagnosticWithPath).Path())
+
+	same := "f2e906be-a93a-48c6-a2cc-99c64e5ab816"
+	resp = modifyPlan("2025-04", "payment-customization", &same)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Empty(t, resp.RequiresReplace)
+
+	other := "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740"
+	resp = modifyPlan("2025-04", "payment-customization", &other)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Equal(t, path.Paths{path.Root("function_id")}, resp.RequiresReplace)
+
+	// Versions without function handles match the handle against titles.
+	resp = modifyPlan("2025-01", "payment-customization", nil)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Equal(t, types.StringValue("f2e906be-a93a-48c6-a2cc-99c64e5ab816"), plannedFunctionID(resp))
+
+	// Nor do they resolve handles when the resource is created.
+	resp = modifyPlan("2025-01", "missing", nil)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Function Handle Not Found", resp.Diagnostics[0].Summary())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "set store_api_version to 2025-04 or later")
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740",
+		Handle:   "hide-payment-methods",
+		Title:    "Payment Customization",
+		APIType:  "payment_customization",
+		AppTitle: "tf-testing",
+	})
+
+	resp = modifyPlan("2025-01", "payment-customization", nil)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Ambiguous Function Handle", resp.Diagnostics[0].Summary())
+
+	resp = modifyPlan("2025-04", "payment-customization", nil)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Equal(t, types.StringValue("f2e906be-a93a-48c6-a2cc-99c64e5ab816"), plannedFunctionID(resp))
+}
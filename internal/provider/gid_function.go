@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ function.Function = (*gidFunction)(nil)
+
+type gidFunction struct{}
+
+func NewGIDFunction() function.Function {
+	return &gidFunction{}
+}
+
+func (f *gidFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "gid"
+}
+
+func (f *gidFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a Shopify global ID",
+		Description: "Builds a Shopify global ID such as gid://shopify/PaymentCustomization/1 from an object type and id.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "The object type, e.g. PaymentCustomization",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "The object id, e.g. 1",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *gidFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var typ, id string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &typ, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	gid, err := shopify.NewGID(typ, id)
+	if err != nil {
+		argument := int64(0)
+		if errors.Is(err, shopify.ErrInvalidGIDID) {
+			argument = 1
+		}
+
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(argument, err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, gid.String()))
+}
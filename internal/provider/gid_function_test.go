@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGIDFunction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Successful build", func(t *testing.T) {
+		resp := runGIDFunction(ctx, "PaymentCustomization", "1")
+
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue("gid://shopify/PaymentCustomization/1"), resp.Result.Value())
+	})
+
+	t.Run("Malformed input", func(t *testing.T) {
+		for _, tc := range []struct {
+			typ, id  string
+			argument int64
+		}{
+			{"", "1", 0},
+			{"paymentCustomization", "1", 0},
+			{"gid://shopify/PaymentCustomization", "1", 0},
+			{"PaymentCustomization", "", 1},
+			{"PaymentCustomization", "1/2", 1},
+		} {
+			resp := runGIDFunction(ctx, tc.typ, tc.id)
+
+			if assert.NotNil(t, resp.Error, tc) && assert.NotNil(t, resp.Error.FunctionArgument, tc) {
+				assert.Equal(t, tc.argument, *resp.Error.FunctionArgument, tc)
+			}
+		}
+	})
+}
+
+func runGIDFunction(ctx context.Context, typ string, id string) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue(typ),
+			types.StringValue(id),
+		}),
+	}
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+
+	NewGIDFunction().Run(ctx, req, resp)
+	return resp
+}
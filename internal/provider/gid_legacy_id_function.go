@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ function.Function = (*gidLegacyIDFunction)(nil)
+
+type gidLegacyIDFunction struct{}
+
+func NewGIDLegacyIDFunction() function.Function {
+	return &gidLegacyIDFunction{}
+}
+
+func (f *gidLegacyIDFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "gid_legacy_id"
+}
+
+func (f *gidLegacyIDFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary:     "Extract the legacy resource ID from a Shopify global ID",
+		Description: "Returns the numeric REST resource ID of a Shopify global ID, e.g. 123 for gid://shopify/DiscountAutomaticNode/123.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "gid",
+				Description: "The global ID to extract the legacy resource ID from",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *gidLegacyIDFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var s string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &s))
+	if resp.Error != nil {
+		return
+	}
+
+	gid, err := shopify.ParseGID(s)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	id, err := gid.LegacyID()
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, id))
+}
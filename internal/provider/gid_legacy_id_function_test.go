@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGIDLegacyIDFunction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Successful extract", func(t *testing.T) {
+		resp := runGIDLegacyIDFunction(ctx, "gid://shopify/DiscountAutomaticNode/123")
+
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue("123"), resp.Result.Value())
+	})
+
+	t.Run("Malformed input", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"123",
+			"gid://shopify/DiscountAutomaticNode",
+			"gid://shopify/ShopifyFunction/07224386-3c16-4f9e-b8ba-da049b6afc66",
+		} {
+			resp := runGIDLegacyIDFunction(ctx, s)
+
+			assert.NotNil(t, resp.Error, s)
+			assert.Equal(t, int64(0), *resp.Error.FunctionArgument, s)
+		}
+	})
+}
+
+func runGIDLegacyIDFunction(ctx context.Context, gid string) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(gid)}),
+	}
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+
+	NewGIDLegacyIDFunction().Run(ctx, req, resp)
+	return resp
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ function.Function = (*gidParseFunction)(nil)
+
+var gidParseReturnAttrTypes = map[string]attr.Type{
+	"type":   types.StringType,
+	"id":     types.StringType,
+	"params": types.MapType{ElemType: types.StringType},
+}
+
+type gidParseFunction struct{}
+
+func NewGIDParseFunction() function.Function {
+	return &gidParseFunction{}
+}
+
+func (f *gidParseFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "gid_parse"
+}
+
+func (f *gidParseFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a Shopify global ID",
+		Description: "Splits a Shopify global ID such as gid://shopify/DiscountAutomaticNode/123 into its type, id and query parameters.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "gid",
+				Description: "The global ID to parse",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: gidParseReturnAttrTypes,
+		},
+	}
+}
+
+func (f *gidParseFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var s string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &s))
+	if resp.Error != nil {
+		return
+	}
+
+	gid, err := shopify.ParseGID(s)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	params, diags := types.MapValueFrom(ctx, types.StringType, gid.Params)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	result, diags := types.ObjectValue(gidParseReturnAttrTypes, map[string]attr.Value{
+		"type":   types.StringValue(gid.Type),
+		"id":     types.StringValue(gid.ID),
+		"params": params,
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGIDParseFunction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Successful parse", func(t *testing.T) {
+		resp := runGIDParseFunction(ctx, "gid://shopify/ImageSource/123?width=100")
+
+		expected := types.ObjectValueMust(gidParseReturnAttrTypes, map[string]attr.Value{
+			"type": types.StringValue("ImageSource"),
+			"id":   types.StringValue("123"),
+			"params": types.MapValueMust(types.StringType, map[string]attr.Value{
+				"width": types.StringValue("100"),
+			}),
+		})
+
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, expected, resp.Result.Value())
+	})
+
+	t.Run("Malformed input", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"DiscountAutomaticNode/123",
+			"gid://shopify/DiscountAutomaticNode",
+			"gid://shopify/DiscountAutomaticNode/",
+			"gid://shopify/discount/123",
+		} {
+			resp := runGIDParseFunction(ctx, s)
+
+			assert.NotNil(t, resp.Error, s)
+			assert.Equal(t, int64(0), *resp.Error.FunctionArgument, s)
+		}
+	})
+}
+
+func runGIDParseFunction(ctx context.Context, gid string) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(gid)}),
+	}
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(gidParseReturnAttrTypes)),
+	}
+
+	NewGIDParseFunction().Run(ctx, req, resp)
+	return resp
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+// idIdentityModel is the identity of a resource that is identified by the
+// global ID of the Shopify object it manages. List results carry it to name
+// the objects they find, and import blocks can use it instead of an ID.
+type idIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// idIdentitySchema returns the identity schema of a resource that manages
+// nodeType objects.
+func idIdentitySchema(nodeType string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       fmt.Sprintf("The global ID of the %s, e.g. gid://shopify/%s/1", nodeType, nodeType),
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// importID imports a resource that manages nodeType objects, setting the id
+// attribute from the import ID or, for an import block with an identity, from
+// the identity's id.
+//
+// The ID must be a nodeType global ID. The legacy "id,function_id" import ID
+// format is still accepted, function_id is read back from Shopify either way.
+func importID(
+	ctx context.Context,
+	nodeType string,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	id, _, _ := strings.Cut(req.ID, ",")
+	if req.ID == "" && req.Identity != nil {
+		var identity idIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		id = identity.ID.ValueString()
+	}
+
+	if id == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import Format",
+			fmt.Sprintf("Please use the resource ID (e.g. gid://shopify/%s/1) or an identity to import the resource", nodeType),
+		)
+
+		return
+	}
+
+	gid, err := shopify.ParseGID(id)
+	if err != nil || gid.Type != nodeType {
+		kind := "not a Shopify global ID"
+		if err == nil {
+			kind = "a " + gid.Type
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Import ID",
+			fmt.Sprintf(
+				"The resource manages %s objects (e.g. gid://shopify/%s/1), but %s is %s.",
+				nodeType,
+				nodeType,
+				id,
+				kind,
+			),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
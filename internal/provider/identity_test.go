@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importResource imports typeName through the provider server, as Terraform
+// does for an import block with either an id or, when identityID is not
+// empty, an identity.
+func importResource(t *testing.T, typeName, id, identityID string) *tfprotov6.ImportResourceStateResponse {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(&funcProvider{version: "test"})()
+	require.NoError(t, err)
+
+	req := &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	}
+
+	if identityID != "" {
+		identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+		require.NoError(t, err)
+
+		req.Identity = &tfprotov6.ResourceIdentityData{
+			IdentityData: objectValue(t, identitySchemas.IdentitySchemas[typeName].ValueType(), map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, identityID),
+			}),
+		}
+	}
+
+	resp, err := server.ImportResourceState(ctx, req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+// importedID returns the id attribute of the only resource imported by resp.
+func importedID(t *testing.T, typeName string, resp *tfprotov6.ImportResourceStateResponse) string {
+	t.Helper()
+
+	require.Empty(t, resp.Diagnostics)
+	require.Len(t, resp.ImportedResources, 1)
+
+	server, err := providerserver.NewProtocol6WithError(&funcProvider{version: "test"})()
+	require.NoError(t, err)
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	state, err := resp.ImportedResources[0].State.Unmarshal(schemas.ResourceSchemas[typeName].ValueType())
+	require.NoError(t, err)
+
+	var attributes map[string]tftypes.Value
+	require.NoError(t, state.As(&attributes))
+
+	var id string
+	require.NoError(t, attributes["id"].As(&id))
+
+	return id
+}
+
+func TestIdentitySchemas(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(&funcProvider{version: "test"})()
+	require.NoError(t, err)
+
+	resp, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	resources := (&funcProvider{}).Resources(context.Background())
+	assert.Len(t, resp.IdentitySchemas, len(resources))
+
+	for typeName, schema := range resp.IdentitySchemas {
+		require.Len(t, schema.IdentityAttributes, 1, typeName)
+		assert.Equal(t, "id", schema.IdentityAttributes[0].Name, typeName)
+		assert.True(t, schema.IdentityAttributes[0].RequiredForImport, typeName)
+	}
+}
+
+func TestImportID(t *testing.T) {
+	for typeName, id := range map[string]string{
+		"shopify_payment":        "gid://shopify/PaymentCustomization/1",
+		"shopify_delivery":       "gid://shopify/DeliveryCustomization/1",
+		"shopify_discount":       "gid://shopify/DiscountAutomaticNode/1",
+		"shopify_pubsub_webhook": "gid://shopify/WebhookSubscription/1",
+	} {
+		t.Run(typeName, func(t *testing.T) {
+			t.Run("id", func(t *testing.T) {
+				assert.Equal(t, id, importedID(t, typeName, importResource(t, typeName, id, "")))
+			})
+
+			t.Run("identity", func(t *testing.T) {
+				assert.Equal(t, id, importedID(t, typeName, importResource(t, typeName, "", id)))
+			})
+		})
+	}
+}
+
+func TestImportID_LegacyFormat(t *testing.T) {
+	resp := importResource(t, "shopify_payment", "gid://shopify/PaymentCustomization/1,f2e906be-a93a-48c6-a2cc-99c64e5ab816", "")
+
+	assert.Equal(t, "gid://shopify/PaymentCustomization/1", importedID(t, "shopify_payment", resp))
+}
+
+func TestImportID_Invalid(t *testing.T) {
+	resp := importResource(t, "shopify_payment", ",f2e906be-a93a-48c6-a2cc-99c64e5ab816", "")
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Invalid Import Format", resp.Diagnostics[0].Summary)
+}
+
+func TestImportID_WrongType(t *testing.T) {
+	for _, tc := range []struct {
+		id         string
+		identityID string
+		detail     string
+	}{
+		{"gid://shopify/DeliveryCustomization/1", "", "but gid://shopify/DeliveryCustomization/1 is a DeliveryCustomization."},
+		{"", "gid://shopify/DeliveryCustomization/1", "but gid://shopify/DeliveryCustomization/1 is a DeliveryCustomization."},
+		{"1", "", "but 1 is not a Shopify global ID."},
+	} {
+		resp := importResource(t, "shopify_payment", tc.id, tc.identityID)
+
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Invalid Import ID", resp.Diagnostics[0].Summary)
+		assert.Contains(t, resp.Diagnostics[0].Detail, "The resource manages PaymentCustomization objects")
+		assert.Contains(t, resp.Diagnostics[0].Detail, tc.detail)
+		assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("id"), resp.Diagnostics[0].Attribute)
+		assert.Empty(t, resp.ImportedResources)
+	}
+}
@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listedResource is a list result decoded with the listed resource's schemas.
+type listedResource struct {
+	DisplayName string
+	ID          string
+	Resource    map[string]tftypes.Value
+}
+
+// listResources runs the list block of typeName, with config as its
+// arguments, through a provider server configured for the fake store, as
+// `terraform query` does.
+func listResources(
+	t *testing.T,
+	server *shopifytest.Server,
+	typeName string,
+	config map[string]tftypes.Value,
+	limit int64,
+) []listedResource {
+	t.Helper()
+
+	ctx := context.Background()
+
+	providerServer, err := providerserver.NewProtocol6WithError(&funcProvider{
+		version:       "test",
+		clientOptions: []shopify.Option{shopify.WithHTTPClient(server.Client())},
+	})()
+	require.NoError(t, err)
+
+	schemas, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemas.Diagnostics)
+
+	providerConfig := objectValue(t, schemas.Provider.ValueType(), map[string]tftypes.Value{
+		"store_domain":       tftypes.NewValue(tftypes.String, shopifytest.StoreDomain),
+		"store_access_token": tftypes.NewValue(tftypes.String, shopifytest.StoreAccessToken),
+		"store_api_version":  tftypes.NewValue(tftypes.String, shopifytest.StoreApiVersion),
+	})
+
+	configureResp, err := providerServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: providerConfig})
+	require.NoError(t, err)
+	// StoreApiVersion only adds a warning once Shopify stops supporting it.
+	for _, d := range configureResp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, d.Detail)
+	}
+
+	listSchema, ok := schemas.ListResourceSchemas[typeName]
+	require.True(t, ok, "%s has no list resource", typeName)
+
+	stream, err := providerServer.(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{
+		TypeName:        typeName,
+		Config:          objectValue(t, listSchema.ValueType(), config),
+		IncludeResource: true,
+		Limit:           limit,
+	})
+	require.NoError(t, err)
+
+	identitySchemas, err := providerServer.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	require.NoError(t, err)
+
+	resourceType := schemas.ResourceSchemas[typeName].ValueType()
+	identityType := identitySchemas.IdentitySchemas[typeName].ValueType()
+
+	listed := []listedResource{}
+	for result := range stream.Results {
+		require.Empty(t, result.Diagnostics)
+
+		identity, err := result.Identity.IdentityData.Unmarshal(identityType)
+		require.NoError(t, err)
+
+		var identityAttributes map[string]tftypes.Value
+		require.NoError(t, identity.As(&identityAttributes))
+
+		var id string
+		require.NoError(t, identityAttributes["id"].As(&id))
+
+		resource, err := result.Resource.Unmarshal(resourceType)
+		require.NoError(t, err)
+
+		var resourceAttributes map[string]tftypes.Value
+		require.NoError(t, resource.As(&resourceAttributes))
+
+		listed = append(listed, listedResource{
+			DisplayName: result.DisplayName,
+			ID:          id,
+			Resource:    resourceAttributes,
+		})
+	}
+
+	return listed
+}
+
+// objectValue returns a DynamicValue of the object type typ, with values set
+// and every other attribute null.
+func objectValue(t *testing.T, typ tftypes.Type, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range typ.(tftypes.Object).AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, attributes))
+	require.NoError(t, err)
+
+	return &dv
+}
+
+func TestListResources(t *testing.T) {
+	ctx := context.Background()
+
+	listResources := (&funcProvider{}).ListResources(ctx)
+	resources := (&funcProvider{}).Resources(ctx)
+
+	assert.Len(t, listResources, len(resources))
+}
+
+func TestPaymentCustomListResource(t *testing.T) {
+	server := newTestAccServer()
+	defer server.Close()
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx := context.Background()
+
+	enabled, err := client.Payment.Create(ctx, "f2e906be-a93a-48c6-a2cc-99c64e5ab816", &shopify.PaymentNode{Title: "Hide COD", Enabled: true})
+	require.NoError(t, err)
+
+	disabled, err := client.Payment.Create(ctx, "f2e906be-a93a-48c6-a2cc-99c64e5ab816", &shopify.PaymentNode{Title: "Reorder"})
+	require.NoError(t, err)
+
+	listed := listResources(t, server, "shopify_payment", nil, 0)
+
+	require.Len(t, listed, 2)
+	assert.Equal(t, enabled.ID, listed[0].ID)
+	assert.Equal(t, "Hide COD", listed[0].DisplayName)
+	assert.Equal(t, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, enabled.ID),
+		"function_id":     tftypes.NewValue(tftypes.String, "f2e906be-a93a-48c6-a2cc-99c64e5ab816"),
+		"function_handle": tftypes.NewValue(tftypes.String, nil),
+		"title":           tftypes.NewValue(tftypes.String, "Hide COD"),
+		"enabled":         tftypes.NewValue(tftypes.Bool, true),
+		"timeouts":        listed[0].Resource["timeouts"],
+	}, listed[0].Resource)
+	assert.True(t, listed[0].Resource["timeouts"].IsNull())
+	assert.Equal(t, disabled.ID, listed[1].ID)
+
+	listed = listResources(t, server, "shopify_payment", map[string]tftypes.Value{
+		"enabled": tftypes.NewValue(tftypes.Bool, false),
+	}, 0)
+
+	require.Len(t, listed, 1)
+	assert.Equal(t, disabled.ID, listed[0].ID)
+
+	listed = listResources(t, server, "shopify_payment", map[string]tftypes.Value{
+		"function_id": tftypes.NewValue(tftypes.String, "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740"),
+	}, 0)
+
+	assert.Empty(t, listed)
+
+	listed = listResources(t, server, "shopify_payment", nil, 1)
+
+	require.Len(t, listed, 1)
+	assert.Equal(t, enabled.ID, listed[0].ID)
+}
+
+func TestDeliveryCustomListResource(t *testing.T) {
+	server := newTestAccServer()
+	defer server.Close()
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx := context.Background()
+
+	delivery, err := client.Delivery.Create(ctx, "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740", &shopify.DeliveryNode{Title: "Rename express", Enabled: true})
+	require.NoError(t, err)
+
+	listed := listResources(t, server, "shopify_delivery", map[string]tftypes.Value{
+		"function_id": tftypes.NewValue(tftypes.String, "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740"),
+		"enabled":     tftypes.NewValue(tftypes.Bool, true),
+	}, 0)
+
+	require.Len(t, listed, 1)
+	assert.Equal(t, delivery.ID, listed[0].ID)
+	assert.Equal(t, "Rename express", listed[0].DisplayName)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, delivery.ID), listed[0].Resource["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740"), listed[0].Resource["function_id"])
+}
+
+func TestDiscountAutomaticListResource(t *testing.T) {
+	server := newTestAccServer()
+	defer server.Close()
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx := context.Background()
+
+	discount, err := client.Discount.Create(ctx, "07224386-3c16-4f9e-b8ba-da049b6afc66", &shopify.DiscountNode{
+		Title:        "Spring sale",
+		StartsAt:     "2024-01-01T00:00:00Z",
+		CombinesWith: &shopify.DiscountCombinesWith{ShippingDiscounts: true},
+	})
+	require.NoError(t, err)
+
+	listed := listResources(t, server, "shopify_discount", map[string]tftypes.Value{
+		"status": tftypes.NewValue(tftypes.String, "ACTIVE"),
+	}, 0)
+
+	require.Len(t, listed, 1)
+	assert.Equal(t, discount.ID, listed[0].ID)
+	assert.Equal(t, "Spring sale", listed[0].DisplayName)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"), listed[0].Resource["starts_at"])
+	assert.True(t, listed[0].Resource["ends_at"].IsNull())
+
+	var combinesWith map[string]tftypes.Value
+	require.NoError(t, listed[0].Resource["combines_with"].As(&combinesWith))
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, true), combinesWith["shipping_discounts"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, false), combinesWith["order_discounts"])
+
+	listed = listResources(t, server, "shopify_discount", map[string]tftypes.Value{
+		"function_id": tftypes.NewValue(tftypes.String, "f2e906be-a93a-48c6-a2cc-99c64e5ab816"),
+	}, 0)
+
+	assert.Empty(t, listed)
+}
+
+func TestPubsubWebhookListResource(t *testing.T) {
+	server := newTestAccServer()
+	defer server.Close()
+
+	server.AddWebhookSubscription("ORDERS_CREATE", "JSON", "https://example.com/webhooks", "", "", "")
+	pubsub := server.AddWebhookSubscription("ORDERS_CREATE", "JSON", "", "", "project", "orders")
+	server.AddWebhookSubscription("ORDERS_PAID", "XML", "", "", "project", "payments")
+
+	listed := listResources(t, server, "shopify_pubsub_webhook", map[string]tftypes.Value{
+		"topics": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "ORDERS_CREATE"),
+		}),
+	}, 0)
+
+	require.Len(t, listed, 1)
+	assert.Equal(t, pubsub, listed[0].ID)
+	assert.Equal(t, "ORDERS_CREATE to project/orders", listed[0].DisplayName)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "project"), listed[0].Resource["pubsub_project"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "orders"), listed[0].Resource["pubsub_topic"])
+
+	listed = listResources(t, server, "shopify_pubsub_webhook", map[string]tftypes.Value{
+		"format": tftypes.NewValue(tftypes.String, "XML"),
+	}, 0)
+
+	require.Len(t, listed, 1)
+	assert.Equal(t, "ORDERS_PAID to project/payments", listed[0].DisplayName)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// streamListResults streams a result for each of nodes, up to the request's
+// limit. id and name give a node's global ID and display name, and model its
+// resource state, which is only set when Terraform asks for it.
+//
+// The framework fails a list result that has no identity as incomplete, and
+// req.NewListResult needs the resource's identity schema, so every resource
+// with a list resource declares idIdentitySchema. The framework then also
+// requires its identity to be set in Create, Read and Update.
+func streamListResults[T any](
+	ctx context.Context,
+	req list.ListRequest,
+	stream *list.ListResultsStream,
+	nodes []T,
+	id func(node T) string,
+	name func(node T) string,
+	model func(node T) any,
+) {
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, node := range nodes {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = name(node)
+			result.Diagnostics.Append(result.Identity.Set(ctx, idIdentityModel{ID: types.StringValue(id(node))})...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, model(node))...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
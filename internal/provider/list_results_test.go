@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListResults_NeedIdentity pins down why the resources with a list
+// resource declare an identity: the framework fails every list result that
+// has none, however complete the rest of it is.
+func TestListResults_NeedIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	providerServer, err := providerserver.NewProtocol6WithError(&noIdentityProvider{})()
+	require.NoError(t, err)
+
+	schemas, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemas.Diagnostics)
+
+	stream, err := providerServer.(tfprotov6.ProviderServerWithListResource).ListResource(ctx, &tfprotov6.ListResourceRequest{
+		TypeName:        "shopify_thing",
+		Config:          objectValue(t, schemas.ListResourceSchemas["shopify_thing"].ValueType(), nil),
+		IncludeResource: true,
+	})
+	require.NoError(t, err)
+
+	var results []tfprotov6.ListResourceResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Diagnostics, 1)
+	assert.Equal(t, "Incomplete List Result", results[0].Diagnostics[0].Summary)
+	assert.Contains(t, results[0].Diagnostics[0].Detail, `The "Identity" field is nil.`)
+}
+
+// noIdentityProvider has one resource, shopify_thing, without an identity
+// schema, and a list resource that finds one of it.
+type noIdentityProvider struct{}
+
+func (p *noIdentityProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "shopify"
+}
+
+func (p *noIdentityProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+func (p *noIdentityProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+func (p *noIdentityProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *noIdentityProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{func() resource.Resource { return noIdentityResource{} }}
+}
+
+func (p *noIdentityProvider) ListResources(context.Context) []func() list.ListResource {
+	return []func() list.ListResource{func() list.ListResource { return noIdentityResource{} }}
+}
+
+type noIdentityResource struct{}
+
+func (noIdentityResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_thing"
+}
+
+func (noIdentityResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func (noIdentityResource) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {}
+
+func (noIdentityResource) Read(context.Context, resource.ReadRequest, *resource.ReadResponse) {}
+
+func (noIdentityResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {}
+
+func (noIdentityResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {}
+
+func (noIdentityResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = listschema.Schema{}
+}
+
+func (noIdentityResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	stream.Results = func(push func(list.ListResult) bool) {
+		// req.NewListResult can't be used: it dereferences the identity
+		// schema the resource doesn't have.
+		result := list.ListResult{
+			DisplayName: "thing",
+			Resource: &tfsdk.Resource{
+				Raw:    tftypes.NewValue(req.ResourceSchema.Type().TerraformType(ctx), nil),
+				Schema: req.ResourceSchema,
+			},
+		}
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), "gid://shopify/Thing/1")...)
+
+		push(result)
+	}
+}
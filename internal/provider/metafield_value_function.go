@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ function.Function = (*metafieldValueFunction)(nil)
+
+type metafieldValueFunction struct{}
+
+func NewMetafieldValueFunction() function.Function {
+	return &metafieldValueFunction{}
+}
+
+func (f *metafieldValueFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "metafield_value"
+}
+
+func (f *metafieldValueFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Encode a metafield value",
+		Description: "Serializes a value into the string Shopify expects for a metafield of the given type, " +
+			"e.g. a money object into {\"amount\":\"5.99\",\"currency_code\":\"USD\"}, and fails when the value doesn't match the type.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "The metafield type, e.g. json, money or list.single_line_text_field",
+			},
+			function.DynamicParameter{
+				Name:        "value",
+				Description: "The value to encode",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *metafieldValueFunction) Run(
+	ctx context.Context,
+	req function.RunRequest,
+	resp *function.RunResponse,
+) {
+	var typ string
+	var value types.Dynamic
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &typ, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, "value must not be null"))
+		return
+	}
+
+	tfValue, err := value.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, err.Error()))
+		return
+	}
+
+	native, err := terraformValueToNative(tfValue)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, err.Error()))
+		return
+	}
+
+	encoded, err := shopify.EncodeMetafieldValue(typ, native)
+	if err != nil {
+		argument := int64(1)
+		if errors.Is(err, shopify.ErrUnsupportedMetafieldType) {
+			argument = 0
+		}
+
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(argument, err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
+
+// terraformValueToNative converts v into the plain Go values
+// shopify.EncodeMetafieldValue works with.
+func terraformValueToNative(v tftypes.Value) (any, error) {
+	if !v.IsFullyKnown() {
+		return nil, fmt.Errorf("value must be known")
+	}
+
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return nil, err
+		}
+
+		return json.Number(n.Text('f', -1)), nil
+	case typ.Is(tftypes.Object{}), typ.Is(tftypes.Map{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return nil, err
+		}
+
+		m := make(map[string]any, len(attrs))
+		for key, attr := range attrs {
+			native, err := terraformValueToNative(attr)
+			if err != nil {
+				return nil, err
+			}
+
+			m[key] = native
+		}
+
+		return m, nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+
+		l := make([]any, 0, len(elems))
+		for _, elem := range elems {
+			native, err := terraformValueToNative(elem)
+			if err != nil {
+				return nil, err
+			}
+
+			l = append(l, native)
+		}
+
+		return l, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", typ)
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetafieldValueFunction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Successful encode", func(t *testing.T) {
+		cases := []struct {
+			typ      string
+			value    attr.Value
+			expected string
+		}{
+			{"single_line_text_field", types.StringValue("hello"), "hello"},
+			{"boolean", types.BoolValue(false), "false"},
+			{"number_integer", types.NumberValue(big.NewFloat(42)), "42"},
+			{
+				"money",
+				types.ObjectValueMust(
+					map[string]attr.Type{"amount": types.NumberType, "currency_code": types.StringType},
+					map[string]attr.Value{
+						"amount":        types.NumberValue(big.NewFloat(5.5)),
+						"currency_code": types.StringValue("USD"),
+					},
+				),
+				`{"amount":"5.5","currency_code":"USD"}`,
+			},
+			{
+				"json",
+				types.MapValueMust(types.StringType, map[string]attr.Value{
+					"tier": types.StringValue("gold"),
+				}),
+				`{"tier":"gold"}`,
+			},
+			{
+				"list.single_line_text_field",
+				types.TupleValueMust(
+					[]attr.Type{types.StringType, types.StringType},
+					[]attr.Value{types.StringValue("a"), types.StringValue("b")},
+				),
+				`["a","b"]`,
+			},
+		}
+
+		for _, c := range cases {
+			resp := runMetafieldValueFunction(ctx, c.typ, types.DynamicValue(c.value))
+
+			assert.Nil(t, resp.Error, c.typ)
+			assert.Equal(t, types.StringValue(c.expected), resp.Result.Value(), c.typ)
+		}
+	})
+
+	t.Run("Mismatched value", func(t *testing.T) {
+		moneyWithoutCurrency := types.ObjectValueMust(
+			map[string]attr.Type{"amount": types.NumberType},
+			map[string]attr.Value{"amount": types.NumberValue(big.NewFloat(5.5))},
+		)
+
+		resp := runMetafieldValueFunction(ctx, "money", types.DynamicValue(moneyWithoutCurrency))
+
+		assert.NotNil(t, resp.Error)
+		assert.Contains(t, resp.Error.Error(), "currency_code")
+		assert.Equal(t, int64(1), *resp.Error.FunctionArgument)
+	})
+
+	t.Run("Unsupported type", func(t *testing.T) {
+		resp := runMetafieldValueFunction(ctx, "unknown_type", types.DynamicValue(types.StringValue("x")))
+
+		assert.NotNil(t, resp.Error)
+		assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+	})
+
+	t.Run("Null value", func(t *testing.T) {
+		resp := runMetafieldValueFunction(ctx, "json", types.DynamicNull())
+
+		assert.NotNil(t, resp.Error)
+		assert.Equal(t, int64(1), *resp.Error.FunctionArgument)
+	})
+}
+
+func runMetafieldValueFunction(ctx context.Context, typ string, value types.Dynamic) *function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(typ), value}),
+	}
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+
+	NewMetafieldValueFunction().Run(ctx, req, resp)
+	return resp
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+// compatibleStateMover moves the state of another resource type into r, the
+// typeName resource, so that a moved block does not destroy and recreate the
+// Shopify object. It takes any source, of this provider or another, whose
+// state fits r's schema at the source's schema version and whose id is a
+// nodeType GID, i.e. the same kind of object r manages. Sources that don't
+// get an error saying why.
+func compatibleStateMover(r resource.ResourceWithUpgradeState, typeName string, nodeType string) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if req.SourceRawState == nil {
+				return
+			}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			target := schemaResp.Schema
+			source := target
+
+			upgrader, upgrade := r.UpgradeState(ctx)[req.SourceSchemaVersion]
+			switch {
+			case req.SourceSchemaVersion == target.Version:
+			case upgrade:
+				source = *upgrader.PriorSchema
+			default:
+				resp.Diagnostics.AddError(
+					"Incompatible Resource Move",
+					fmt.Sprintf(
+						"%s can't be moved to %s: its schema version %d is not one %s knows. "+
+							"Upgrade the provider that manages %s, or remove it from state and import it as %s.",
+						req.SourceTypeName,
+						typeName,
+						req.SourceSchemaVersion,
+						typeName,
+						req.SourceTypeName,
+						typeName,
+					),
+				)
+
+				return
+			}
+
+			raw, err := req.SourceRawState.Unmarshal(source.Type().TerraformType(ctx))
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Incompatible Resource Move",
+					fmt.Sprintf(
+						"%s can't be moved to %s, their attributes differ: %s. "+
+							"Remove it from state and import it as %s instead.",
+						req.SourceTypeName,
+						typeName,
+						err,
+						typeName,
+					),
+				)
+
+				return
+			}
+
+			state := tfsdk.State{Schema: source, Raw: raw}
+			if !checkMovedNode(ctx, state, req.SourceTypeName, typeName, nodeType, resp) {
+				return
+			}
+
+			if !upgrade {
+				resp.TargetState = state
+				return
+			}
+
+			upgradeResp := resource.UpgradeStateResponse{
+				State: tfsdk.State{Schema: target, Raw: tftypes.NewValue(target.Type().TerraformType(ctx), nil)},
+			}
+
+			upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &state}, &upgradeResp)
+			resp.Diagnostics.Append(upgradeResp.Diagnostics...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			resp.TargetState = upgradeResp.State
+		},
+	}
+}
+
+// checkMovedNode reports whether the id in state is a nodeType GID, and adds
+// an error to resp if it is not.
+func checkMovedNode(
+	ctx context.Context,
+	state tfsdk.State,
+	sourceTypeName string,
+	typeName string,
+	nodeType string,
+	resp *resource.MoveStateResponse,
+) bool {
+	var id types.String
+	resp.Diagnostics.Append(state.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	gid, err := shopify.ParseGID(id.ValueString())
+	if err == nil && gid.Type == nodeType {
+		return true
+	}
+
+	kind := "not a Shopify global ID"
+	if err == nil {
+		kind = "a " + gid.Type
+	}
+
+	resp.Diagnostics.AddError(
+		"Incompatible Resource Move",
+		fmt.Sprintf(
+			"%s can't be moved to %s: %s manages %s objects, but %s is %s. "+
+				"Moving it would leave %s pointing at the wrong kind of object.",
+			sourceTypeName,
+			typeName,
+			typeName,
+			nodeType,
+			id.ValueString(),
+			kind,
+			typeName,
+		),
+	)
+
+	return false
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPaymentStateV0 = `{
+	"id": "gid://shopify/PaymentCustomization/1",
+	"function_id": "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+	"title": "test_payment",
+	"enabled": true
+}`
+
+// moveState moves the state JSON of sourceTypeName, written at version, to
+// targetTypeName through the provider server, as Terraform does for a moved
+// block.
+func moveState(
+	t *testing.T,
+	sourceTypeName string,
+	version int64,
+	json string,
+	targetTypeName string,
+) *tfprotov6.MoveResourceStateResponse {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(&funcProvider{version: "test"})()
+	require.NoError(t, err)
+
+	resp, err := server.MoveResourceState(context.Background(), &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/pseudomonarchia/shopify",
+		SourceTypeName:        sourceTypeName,
+		SourceSchemaVersion:   version,
+		SourceState:           &tfprotov6.RawState{JSON: []byte(json)},
+		TargetTypeName:        targetTypeName,
+	})
+	require.NoError(t, err)
+
+	return resp
+}
+
+func movedState(t *testing.T, r resource.Resource, resp *tfprotov6.MoveResourceStateResponse) map[string]tftypes.Value {
+	t.Helper()
+
+	require.Empty(t, resp.Diagnostics)
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	moved, err := resp.TargetState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	require.NoError(t, err)
+
+	var attributes map[string]tftypes.Value
+	require.NoError(t, moved.As(&attributes))
+
+	return attributes
+}
+
+func TestMoveState_CompatibleAlias(t *testing.T) {
+	resp := moveState(t, "shopify_payment_customization", 1, `{
+		"id": "gid://shopify/PaymentCustomization/1",
+		"function_id": "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		"function_handle": null,
+		"title": "test_payment",
+		"enabled": true,
+		"timeouts": null
+	}`, "shopify_payment")
+
+	state := movedState(t, NewPaymentCustomResource(), resp)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "gid://shopify/PaymentCustomization/1"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "f2e906be-a93a-48c6-a2cc-99c64e5ab816"), state["function_id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "test_payment"), state["title"])
+}
+
+func TestMoveState_UpgradesSource(t *testing.T) {
+	resp := moveState(t, "shopify_payment_customization", 0, testPaymentStateV0, "shopify_payment")
+
+	state := movedState(t, NewPaymentCustomResource(), resp)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "gid://shopify/PaymentCustomization/1"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, true), state["enabled"])
+	assert.True(t, state["function_handle"].IsNull())
+	assert.True(t, state["timeouts"].IsNull())
+}
+
+func TestMoveState_Discount(t *testing.T) {
+	resp := moveState(t, "shopify_discount_automatic_app", 0, `{
+		"id": "gid://shopify/DiscountAutomaticNode/1",
+		"function_id": "07224386-3c16-4f9e-b8ba-da049b6afc66",
+		"title": "test_discount",
+		"starts_at": "2024-01-01T00:00:00Z",
+		"ends_at": "2024-02-01T00:00:00Z",
+		"combines_with": {
+			"order_discounts": true,
+			"product_discounts": false,
+			"shipping_discounts": false
+		}
+	}`, "shopify_discount")
+
+	state := movedState(t, NewDiscountAutomaticResource(), resp)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "gid://shopify/DiscountAutomaticNode/1"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "2024-02-01T00:00:00Z"), state["ends_at"])
+}
+
+func TestMoveState_OtherNodeType(t *testing.T) {
+	// Payment and delivery customizations have the same attributes, but
+	// are different objects.
+	resp := moveState(t, "shopify_payment", 0, testPaymentStateV0, "shopify_delivery")
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Incompatible Resource Move", resp.Diagnostics[0].Summary)
+	assert.Contains(
+		t,
+		resp.Diagnostics[0].Detail,
+		"shopify_delivery manages DeliveryCustomization objects, but gid://shopify/PaymentCustomization/1 is a PaymentCustomization.",
+	)
+}
+
+func TestMoveState_OtherAttributes(t *testing.T) {
+	resp := moveState(t, "shopify_pubsub_webhook", 0, `{
+		"id": "gid://shopify/WebhookSubscription/1",
+		"topic": "ORDERS_CREATE",
+		"format": "JSON",
+		"pubsub_project": "project",
+		"pubsub_topic": "topic"
+	}`, "shopify_payment")
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Incompatible Resource Move", resp.Diagnostics[0].Summary)
+	assert.Contains(t, resp.Diagnostics[0].Detail, "shopify_pubsub_webhook can't be moved to shopify_payment, their attributes differ")
+}
+
+func TestMoveState_UnknownVersion(t *testing.T) {
+	resp := moveState(t, "shopify_payment_customization", 7, testPaymentStateV0, "shopify_payment")
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Incompatible Resource Move", resp.Diagnostics[0].Summary)
+	assert.Contains(t, resp.Diagnostics[0].Detail, "its schema version 7 is not one shopify_payment knows")
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ list.ListResource = (*paymentCustomListResource)(nil)
+var _ list.ListResourceWithConfigure = (*paymentCustomListResource)(nil)
+
+type paymentCustomListResource struct {
+	client *shopify.ShopifyAdminClinetImpl
+}
+
+type paymentCustomListResourceModel struct {
+	FunctionID types.String `tfsdk:"function_id"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+}
+
+func NewPaymentCustomListResource() list.ListResource {
+	return &paymentCustomListResource{}
+}
+
+func (r *paymentCustomListResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_payment"
+}
+
+func (r *paymentCustomListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the store's payment customizations",
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Description: "Only list payment customizations backed by this function",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+						"Must be a valid UUID",
+					),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only list enabled, or only disabled, payment customizations",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *paymentCustomListResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*shopify.ShopifyAdminClinetImpl)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *shopify.ShopifyAdminClinetImpl, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = c
+}
+
+func (r *paymentCustomListResource) List(
+	ctx context.Context,
+	req list.ListRequest,
+	stream *list.ListResultsStream,
+) {
+	var config paymentCustomListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	payments, err := r.client.Payment.List(ctx, customizationFilter(config.FunctionID, config.Enabled))
+	if err != nil {
+		diags.AddError("Failed to list shopify payment customizations", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	streamListResults(ctx, req, stream, payments,
+		func(p shopify.PaymentNode) string { return p.ID },
+		func(p shopify.PaymentNode) string { return p.Title },
+		func(p shopify.PaymentNode) any {
+			return paymentCustomResourceModel{
+				FunctionID:     types.StringValue(p.FunctionID),
+				FunctionHandle: types.StringNull(),
+				ID:             types.StringValue(p.ID),
+				Title:          types.StringValue(p.Title),
+				Enabled:        types.BoolValue(p.Enabled),
+				Timeouts:       nullTimeouts(),
+			}
+		},
+	)
+}
+
+// customizationFilter returns the Shopify filter for a payment or delivery
+// customization list block's function_id and enabled arguments.
+func customizationFilter(functionID types.String, enabled types.Bool) *shopify.CustomizationFilter {
+	filter := &shopify.CustomizationFilter{
+		FunctionID: functionID.ValueString(),
+	}
+
+	if !enabled.IsNull() {
+		v := enabled.ValueBool()
+		filter.Enabled = &v
+	}
+
+	return filter
+}
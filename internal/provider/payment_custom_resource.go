@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,12 +18,26 @@ import (
 )
 
 var _ resource.Resource = (*paymentCustomResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*paymentCustomResource)(nil)
+var _ resource.ResourceWithUpgradeState = (*paymentCustomResource)(nil)
+var _ resource.ResourceWithMoveState = (*paymentCustomResource)(nil)
+var _ resource.ResourceWithIdentity = (*paymentCustomResource)(nil)
 
 type paymentCustomResource struct {
 	client *shopify.ShopifyAdminClinetImpl
 }
 
 type paymentCustomResourceModel struct {
+	FunctionID     types.String   `tfsdk:"function_id"`
+	FunctionHandle types.String   `tfsdk:"function_handle"`
+	ID             types.String   `tfsdk:"id"`
+	Title          types.String   `tfsdk:"title"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// paymentCustomResourceModelV0 is state at schema version 0.
+type paymentCustomResourceModelV0 struct {
 	FunctionID types.String `tfsdk:"function_id"`
 	ID         types.String `tfsdk:"id"`
 	Title      types.String `tfsdk:"title"`
@@ -43,12 +57,13 @@ func (r *paymentCustomResource) Metadata(
 }
 
 func (r *paymentCustomResource) Schema(
-	_ context.Context,
+	ctx context.Context,
 	_ resource.SchemaRequest,
 	resp *resource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
 		Description: "Shopify Function Payment Customization Resource",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -57,8 +72,11 @@ func (r *paymentCustomResource) Schema(
 				},
 			},
 			"function_id": schema.StringAttribute{
-				Required: true,
+				Description: "The ID of a function that implements the payment_customization Function API. Set this or function_handle",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -66,6 +84,14 @@ func (r *paymentCustomResource) Schema(
 						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
 						"Must be a valid UUID",
 					),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("function_handle")),
+				},
+			},
+			"function_handle": schema.StringAttribute{
+				Description: "The handle of the function, as set in its shopify.extension.toml. Unlike function_id, it is the same on every store the app is installed on",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"title": schema.StringAttribute{
@@ -78,6 +104,70 @@ func (r *paymentCustomResource) Schema(
 				Required: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *paymentCustomResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("PaymentCustomization")
+}
+
+func (r *paymentCustomResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// State written before the timeouts block and function_handle.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"function_id": schema.StringAttribute{
+						Required: true,
+					},
+					"title": schema.StringAttribute{
+						Required: true,
+					},
+					"enabled": schema.BoolAttribute{
+						Required: true,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior paymentCustomResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := paymentCustomResourceModel{
+					FunctionID:     prior.FunctionID,
+					FunctionHandle: types.StringNull(),
+					ID:             prior.ID,
+					Title:          prior.Title,
+					Enabled:        prior.Enabled,
+					Timeouts:       nullTimeouts(),
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+func (r *paymentCustomResource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		compatibleStateMover(r, "shopify_payment", "PaymentCustomization"),
 	}
 }
 
@@ -106,34 +196,61 @@ func (r *paymentCustomResource) Configure(
 	r.client = c
 }
 
+func (r *paymentCustomResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	checkAccessScopes(ctx, r.client, "shopify_payment", resourceAccessScopes["shopify_payment"], &resp.Diagnostics)
+	planFunctionHandle(ctx, r.client, req, resp)
+	checkFunctionAPIType(ctx, r.client, resp.Plan, "shopify_payment", &resp.Diagnostics)
+}
+
 func (r *paymentCustomResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_payment")
+	defer addDeprecationWarnings(r.client, "shopify_payment", &resp.Diagnostics)
+
 	var data paymentCustomResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_payment", "create", createTimeout)
+	defer cancel()
+
 	pn := &shopify.PaymentNode{
-		Title:   data.Title.ValueString(),
-		Enabled: data.Enabled.ValueBool(),
+		FunctionHandle: data.FunctionHandle.ValueString(),
+		Title:          data.Title.ValueString(),
+		Enabled:        data.Enabled.ValueBool(),
 	}
 
 	q, err := r.client.Payment.Create(ctx, data.FunctionID.ValueString(), pn)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create shopify payment customization", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to create shopify payment customization", err)
 		return
 	}
 
-	data.FunctionID = types.StringValue(data.FunctionID.ValueString())
+	if q.FunctionID != "" {
+		data.FunctionID = types.StringValue(q.FunctionID)
+	}
+
 	data.ID = types.StringValue(q.ID)
 	data.Title = types.StringValue(q.Title)
 	data.Enabled = types.BoolValue(q.Enabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *paymentCustomResource) Read(
@@ -141,12 +258,29 @@ func (r *paymentCustomResource) Read(
 	req resource.ReadRequest,
 	resp *resource.ReadResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_payment")
+	defer addDeprecationWarnings(r.client, "shopify_payment", &resp.Diagnostics)
+
 	var data paymentCustomResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_payment", "read", readTimeout)
+	defer cancel()
 
 	q, err := r.client.Payment.Get(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get shopify payment customization", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to get shopify payment customization", err)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -157,6 +291,10 @@ func (r *paymentCustomResource) Read(
 	data.Title = types.StringValue(q.Title)
 	data.Enabled = types.BoolValue(q.Enabled)
 
+	if q.FunctionID != "" {
+		data.FunctionID = types.StringValue(q.FunctionID)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -165,12 +303,24 @@ func (r *paymentCustomResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_payment")
+	defer addDeprecationWarnings(r.client, "shopify_payment", &resp.Diagnostics)
+
 	var data paymentCustomResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_payment", "update", updateTimeout)
+	defer cancel()
+
 	pn := &shopify.PaymentNode{
 		ID:      data.ID.ValueString(),
 		Title:   data.Title.ValueString(),
@@ -179,7 +329,7 @@ func (r *paymentCustomResource) Update(
 
 	q, err := r.client.Payment.Update(ctx, pn)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update shopify payment customization", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to update shopify payment customization", err)
 		return
 	}
 
@@ -188,6 +338,7 @@ func (r *paymentCustomResource) Update(
 	data.Enabled = types.BoolValue(q.Enabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *paymentCustomResource) Delete(
@@ -195,16 +346,27 @@ func (r *paymentCustomResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_payment")
+	defer addDeprecationWarnings(r.client, "shopify_payment", &resp.Diagnostics)
+
 	var data paymentCustomResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, "shopify_payment", "delete", deleteTimeout)
+	defer cancel()
+
 	_, err := r.client.Payment.Delete(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete shopify payment customization", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to delete shopify payment customization", err)
 		return
 	}
 }
@@ -214,16 +376,5 @@ func (r *paymentCustomResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	idParts := strings.Split(req.ID, ",")
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Invalid Import Format",
-			"Please use the format 'id,function_id' to import the resource",
-		)
-
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("function_id"), idParts[1])...)
+	importID(ctx, "PaymentCustomization", req, resp)
 }
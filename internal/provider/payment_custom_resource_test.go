@@ -2,15 +2,17 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccPaymentCustomResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccPaymentCustomResourceConfig("test_payment", true),
@@ -32,7 +34,6 @@ func TestAccPaymentCustomResource(t *testing.T) {
 				ResourceName:      "shopify_payment.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				ImportStateIdFunc: testAccPaymentCustomResourceImportStateIdFunc,
 			},
 		},
 	})
@@ -52,11 +53,159 @@ func testAccPaymentCustomResourceConfig(title string, enabled bool) string {
 	)
 }
 
-func testAccPaymentCustomResourceImportStateIdFunc(s *terraform.State) (string, error) {
-	rs, ok := s.RootModule().Resources["shopify_payment.test"]
-	if !ok {
-		return "", fmt.Errorf("Resource not found: shopify_payment.test")
+func TestAccPaymentCustomResource_Timeout(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("latency is only injected into the fake store")
 	}
 
-	return fmt.Sprintf("%s,%s", rs.Primary.ID, rs.Primary.Attributes["function_id"]), nil
+	testAccServer.SetLatency(time.Second)
+	t.Cleanup(func() { testAccServer.SetLatency(0) })
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "shopify_payment" "test" {
+						function_id = "f2e906be-a93a-48c6-a2cc-99c64e5ab816"
+						title       = "slow_payment"
+						enabled     = true
+
+						timeouts {
+							create = "100ms"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("Shopify Operation Timed Out"),
+			},
+		},
+	})
+}
+
+func TestAccPaymentCustomResource_WrongFunctionAPIType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				// The delivery-customization function.
+				Config: `
+					resource "shopify_payment" "test" {
+						function_id = "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740"
+						title       = "test_payment"
+						enabled     = true
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Wrong Shopify Function API Type"),
+			},
+		},
+	})
+}
+
+func TestAccPaymentCustomResource_FunctionHandle(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("function handles are only tested against the fake store")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPaymentCustomResourceConfig_FunctionHandle(`function_handle = "payment-customization"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("shopify_payment.test", "function_handle", "payment-customization"),
+					resource.TestCheckResourceAttr("shopify_payment.test", "function_id", "f2e906be-a93a-48c6-a2cc-99c64e5ab816"),
+				),
+			},
+			{
+				// The same function by ID is not a new function.
+				Config: testAccPaymentCustomResourceConfig_FunctionHandle(`function_id = "f2e906be-a93a-48c6-a2cc-99c64e5ab816"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("shopify_payment.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config:      testAccPaymentCustomResourceConfig_FunctionHandle(`function_handle = "delivery-customization"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Wrong Shopify Function API Type"),
+			},
+		},
+	})
+}
+
+func testAccPaymentCustomResourceConfig_FunctionHandle(function string) string {
+	return fmt.Sprintf(
+		`
+			provider "shopify" {
+				store_api_version = "2025-04"
+			}
+
+			resource "shopify_payment" "test" {
+				%s
+				title   = "test_payment"
+				enabled = true
+			}
+		`,
+		function,
+	)
+}
+
+func TestAccPaymentCustomResource_FunctionHandleNotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				// 2025-01 doesn't resolve handles on create, so one that no
+				// function's title matches fails the plan.
+				Config: `
+					provider "shopify" {
+						store_api_version = "2025-01"
+					}
+
+					resource "shopify_payment" "test" {
+						function_handle = "missing"
+						title           = "test_payment"
+						enabled         = true
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Function Handle Not Found"),
+			},
+			{
+				// Nor does a version that accepts handles, where an unknown
+				// function_id would replace the resource on every plan.
+				Config: `
+					resource "shopify_payment" "test" {
+						function_handle = "missing"
+						title           = "test_payment"
+						enabled         = true
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Function Handle Not Found"),
+			},
+		},
+	})
+}
+
+func TestAccPaymentCustomResource_FunctionIDAndHandle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "shopify_payment" "test" {
+						function_id     = "f2e906be-a93a-48c6-a2cc-99c64e5ab816"
+						function_handle = "payment-customization"
+						title           = "test_payment"
+						enabled         = true
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
 }
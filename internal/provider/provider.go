@@ -2,29 +2,53 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
 )
 
 var _ provider.Provider = (*funcProvider)(nil)
+var _ provider.ProviderWithFunctions = (*funcProvider)(nil)
+var _ provider.ProviderWithListResources = (*funcProvider)(nil)
 
 type funcProvider struct {
-	version string
+	version       string
+	clientOptions []shopify.Option
+	// now returns the time store_api_version is checked at. Defaults to
+	// time.Now.
+	now func() time.Time
 }
 
 type funcProviderModel struct {
-	StoreDomain      types.String `tfsdk:"store_domain"`
-	StoreAccessToken types.String `tfsdk:"store_access_token"`
-	StoreApiVersion  types.String `tfsdk:"store_api_version"`
+	StoreDomain        types.String `tfsdk:"store_domain"`
+	StoreAccessToken   types.String `tfsdk:"store_access_token"`
+	StoreApiVersion    types.String `tfsdk:"store_api_version"`
+	MaxIdleConnections types.Int64  `tfsdk:"max_idle_connections"`
+	CacheFunctions     types.Bool   `tfsdk:"cache_functions"`
+	OAuth              *oauthModel  `tfsdk:"oauth"`
+}
+
+type oauthModel struct {
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	SessionToken types.String `tfsdk:"session_token"`
 }
 
 func New(version string) func() provider.Provider {
@@ -63,11 +87,12 @@ func (p *funcProvider) Schema(
 				},
 			},
 			"store_access_token": schema.StringAttribute{
-				Description: "The store's access token",
+				Description: "The store's access token. Conflicts with `oauth`",
 				Sensitive:   true,
 				Optional:    true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("oauth")),
 				},
 			},
 			"store_api_version": schema.StringAttribute{
@@ -76,10 +101,58 @@ func (p *funcProvider) Schema(
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(
 						regexp.MustCompile(`^\d{4}-\d{2}$`),
-						"must be a valid Shopify API version (e.g., 2024-07)",
+						"must be a valid Shopify API version (e.g., 2026-10)",
 					),
 				},
 			},
+			"max_idle_connections": schema.Int64Attribute{
+				Description: fmt.Sprintf(
+					"The number of idle connections to the store kept open between operations. Defaults to %d",
+					shopify.DefaultMaxIdleConns,
+				),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"cache_functions": schema.BoolAttribute{
+				Description: "Whether to list the store's functions once and share the result between shopify_function data sources. " +
+					"The list is fetched again after any write, and at least every five minutes to find newly deployed functions. Defaults to true",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"oauth": schema.SingleNestedBlock{
+				Description: "Fetch access tokens with the app's credentials instead of using a static store_access_token. " +
+					"With session_token the token exchange grant is used, otherwise the client credentials grant. " +
+					"Tokens are cached and fetched again when Shopify rejects them",
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						Description: "The app's client ID. Defaults to the SHOPIFY_CLIENT_ID environment variable",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"client_secret": schema.StringAttribute{
+						Description: "The app's client secret. Defaults to the SHOPIFY_CLIENT_SECRET environment variable",
+						Sensitive:   true,
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"session_token": schema.StringAttribute{
+						Description: "A session token to exchange for an offline access token. " +
+							"Defaults to the SHOPIFY_SESSION_TOKEN environment variable",
+						Sensitive: true,
+						Optional:  true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -103,11 +176,42 @@ func (p *funcProvider) Configure(
 		)
 	}
 
+	var oauth *shopify.OAuth
 	storeAccessToken := readOrEnvDefault(conf.StoreAccessToken, "SHOPIFY_STORE_ACCESS_TOKEN")
-	if storeAccessToken == "" {
+	if conf.OAuth != nil || (storeAccessToken == "" && os.Getenv("SHOPIFY_CLIENT_ID") != "") {
+		oauthConf := conf.OAuth
+		if oauthConf == nil {
+			oauthConf = &oauthModel{
+				ClientID:     types.StringNull(),
+				ClientSecret: types.StringNull(),
+				SessionToken: types.StringNull(),
+			}
+		}
+
+		oauth = &shopify.OAuth{
+			ClientID:     readOrEnvDefault(oauthConf.ClientID, "SHOPIFY_CLIENT_ID"),
+			ClientSecret: readOrEnvDefault(oauthConf.ClientSecret, "SHOPIFY_CLIENT_SECRET"),
+			SessionToken: readOrEnvDefault(oauthConf.SessionToken, "SHOPIFY_SESSION_TOKEN"),
+		}
+
+		if oauth.ClientID == "" {
+			resp.Diagnostics.AddError(
+				"Missing Shopify OAuth Client ID",
+				"The oauth block's client_id is not set and no default value is provided.",
+			)
+		}
+
+		if oauth.ClientSecret == "" {
+			resp.Diagnostics.AddError(
+				"Missing Shopify OAuth Client Secret",
+				"The oauth block's client_secret is not set and no default value is provided.",
+			)
+		}
+	} else if storeAccessToken == "" {
 		resp.Diagnostics.AddError(
 			"Missing Shopify Store Access Token",
-			"The Shopify store access token is not set and no default value is provided.",
+			"The Shopify store access token is not set and no default value is provided. "+
+				"Set store_access_token or configure the oauth block.",
 		)
 	}
 
@@ -123,19 +227,74 @@ func (p *funcProvider) Configure(
 		return
 	}
 
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+
+	switch support, err := shopify.CheckApiVersion(storeApiVersion, now()); {
+	case err != nil:
+		resp.Diagnostics.AddError("Invalid Shopify Store API Version", err.Error())
+		return
+	case support == shopify.ApiVersionUnsupported:
+		resp.Diagnostics.AddWarning(
+			"Unsupported Shopify Store API Version",
+			fmt.Sprintf(
+				"Shopify stopped supporting API version %s on %s and answers its requests with the oldest supported version instead. "+
+					"Set store_api_version to a version Shopify released in the last year.",
+				storeApiVersion,
+				shopify.ApiVersionSupportEnds(storeApiVersion).Format(time.DateOnly),
+			),
+		)
+	case support == shopify.ApiVersionUntested:
+		// Shopify releases a version every quarter, so a supported version is
+		// usually newer than the validated ones. That is expected, not a
+		// problem to warn about on every run.
+		tflog.Info(ctx, "Shopify store API version is newer than the versions this provider has been validated against", map[string]any{
+			"store_api_version":  storeApiVersion,
+			"validated_versions": strings.Join(shopify.ApiVersions(), ", "),
+		})
+	}
+
+	opts := slices.Clip(p.clientOptions)
+	if oauth != nil {
+		storeAccessToken = ""
+		opts = append(opts, shopify.WithOAuth(*oauth))
+	}
+
+	if !conf.MaxIdleConnections.IsNull() {
+		opts = append(opts, shopify.WithMaxIdleConns(int(conf.MaxIdleConnections.ValueInt64())))
+	}
+
+	if !conf.CacheFunctions.IsNull() {
+		opts = append(opts, shopify.WithFunctionCache(conf.CacheFunctions.ValueBool()))
+	}
+
 	c := shopify.New(
 		storeDomain,
 		storeAccessToken,
 		storeApiVersion,
+		opts...,
 	)
 
+	if oauth != nil {
+		if err := c.Authenticate(ctx); err != nil {
+			resp.Diagnostics.AddError("Failed to Authenticate with Shopify", err.Error())
+			return
+		}
+	}
+
 	resp.ResourceData = c
 	resp.DataSourceData = c
+	resp.ListResourceData = c
 }
 
 func (p *funcProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewFunctionDataSource,
+		NewWebhookSubscriptionsDataSource,
+		NewAppInstallationDataSource,
+		NewShopDataSource,
 	}
 }
 
@@ -148,6 +307,24 @@ func (p *funcProvider) Resources(_ context.Context) []func() resource.Resource {
 	}
 }
 
+func (p *funcProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewDiscountAutomaticListResource,
+		NewPaymentCustomListResource,
+		NewDeliveryCustomListResource,
+		NewPubsubWebhookListResource,
+	}
+}
+
+func (p *funcProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewGIDParseFunction,
+		NewGIDFunction,
+		NewGIDLegacyIDFunction,
+		NewMetafieldValueFunction,
+	}
+}
+
 func readOrEnvDefault(str types.String, envVarKey string) string {
 	if !str.IsNull() {
 		return str.ValueString()
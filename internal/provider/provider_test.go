@@ -1,22 +1,168 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"shopify": providerserver.NewProtocol6WithError(New("test")()),
+const (
+	testAccOAuthClientID     = "shopifytest-client-id"
+	testAccOAuthClientSecret = "shopifytest-client-secret"
+)
+
+// testAccServer is the fake store the acceptance tests run against when no
+// real store or cassette is configured.
+var testAccServer *shopifytest.Server
+
+// TestMain picks where the acceptance tests send their requests:
+//
+//   - SHOPIFY_RECORD=1 runs them against the store in SHOPIFY_STORE_DOMAIN
+//     and records every test to testdata/cassettes/<TestName>.json.
+//   - SHOPIFY_REPLAY=1 replays the recorded cassettes and skips tests that
+//     have none.
+//   - SHOPIFY_STORE_DOMAIN alone runs them against a real store.
+//   - Otherwise they run against an in-memory fake store.
+func TestMain(m *testing.M) {
+	switch {
+	case os.Getenv("SHOPIFY_RECORD") != "":
+		if os.Getenv("SHOPIFY_STORE_DOMAIN") == "" {
+			fmt.Fprintln(os.Stderr, "SHOPIFY_RECORD requires SHOPIFY_STORE_DOMAIN, SHOPIFY_STORE_ACCESS_TOKEN and SHOPIFY_STORE_API_VERSION")
+			os.Exit(1)
+		}
+	case os.Getenv("SHOPIFY_REPLAY") != "":
+		os.Setenv("SHOPIFY_STORE_DOMAIN", shopifytest.StoreDomain)
+		os.Setenv("SHOPIFY_STORE_ACCESS_TOKEN", shopifytest.StoreAccessToken)
+	case os.Getenv("SHOPIFY_STORE_DOMAIN") == "":
+		testAccServer = newTestAccServer()
+
+		os.Setenv("SHOPIFY_STORE_DOMAIN", shopifytest.StoreDomain)
+		os.Setenv("SHOPIFY_STORE_ACCESS_TOKEN", shopifytest.StoreAccessToken)
+		os.Setenv("SHOPIFY_STORE_API_VERSION", shopifytest.StoreApiVersion)
+	}
+
+	code := m.Run()
+	if testAccServer != nil {
+		testAccServer.Close()
+	}
+
+	os.Exit(code)
+}
+
+// testAccProtoV6ProviderFactories returns the provider factories for the
+// acceptance test t, wired to the fake store, a cassette or a real store as
+// chosen in TestMain.
+func testAccProtoV6ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+
+	// Cassettes are matched request by request, so reads are not coalesced
+	// when recording or replaying: which reads share a query depends on
+	// timing.
+	var opts []shopify.Option
+
+	switch {
+	case os.Getenv("SHOPIFY_RECORD") != "":
+		recorder, err := shopifytest.NewRecorder(
+			testAccCassettePath(t),
+			shopifytest.ModeRecord,
+			nil,
+			os.Getenv("SHOPIFY_STORE_ACCESS_TOKEN"),
+			os.Getenv("SHOPIFY_CLIENT_SECRET"),
+			os.Getenv("SHOPIFY_SESSION_TOKEN"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recorder.SetApiVersion(os.Getenv("SHOPIFY_STORE_API_VERSION"))
+		t.Cleanup(func() {
+			if err := recorder.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		opts = append(opts, shopify.WithHTTPClient(recorder.Client()), shopify.WithReadCoalescing(0))
+	case os.Getenv("SHOPIFY_REPLAY") != "":
+		recorder, err := shopifytest.NewRecorder(testAccCassettePath(t), shopifytest.ModeReplay, nil)
+		if shopifytest.IsNotExist(err) {
+			t.Skipf("no cassette recorded for %s", t.Name())
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Setenv("SHOPIFY_STORE_API_VERSION", recorder.ApiVersion())
+		t.Cleanup(func() {
+			if err := recorder.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		opts = append(opts, shopify.WithHTTPClient(recorder.Client()), shopify.WithReadCoalescing(0))
+	case testAccServer != nil:
+		opts = append(opts, shopify.WithHTTPClient(testAccServer.Client()))
+	}
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"shopify": providerserver.NewProtocol6WithError(&funcProvider{
+			version:       "test",
+			clientOptions: opts,
+		}),
+	}
+}
+
+func testAccCassettePath(t *testing.T) string {
+	return filepath.Join("testdata", "cassettes", filepath.FromSlash(t.Name())+".json")
+}
+
+func newTestAccServer() *shopifytest.Server {
+	server := shopifytest.NewServer()
+	server.AddOAuthClient(testAccOAuthClientID, testAccOAuthClientSecret)
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "07224386-3c16-4f9e-b8ba-da049b6afc66",
+		Handle:   "product-discount",
+		Title:    "product-discount",
+		APIType:  "product_discounts",
+		AppTitle: "tf-testing",
+	})
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		Handle:   "payment-customization",
+		Title:    "payment-customization",
+		APIType:  "payment_customization",
+		AppTitle: "tf-testing",
+	})
+
+	server.AddFunction(shopifytest.Function{
+		ID:       "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740",
+		Handle:   "delivery-customization",
+		Title:    "delivery-customization",
+		APIType:  "delivery_customization",
+		AppTitle: "tf-testing",
+	})
+
+	return server
 }
 
 func TestAccProvider(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccProviderConfig(),
@@ -30,6 +176,71 @@ func TestAccProvider(t *testing.T) {
 	})
 }
 
+func TestAccProvider_InvalidApiVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "shopify" {
+						store_api_version = "2024-02"
+					}
+
+					data "shopify_function" "test" {
+						title     = "product-discount"
+						app_title = "tf-testing"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Shopify Store API Version"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_OAuth(t *testing.T) {
+	if testAccServer == nil {
+		t.Skip("OAuth is only tested against the fake store")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "shopify" {
+						oauth {
+							client_id     = %q
+							client_secret = %q
+						}
+					}
+
+					data "shopify_function" "test" {
+						title     = "product-discount"
+						app_title = "tf-testing"
+					}
+				`, testAccOAuthClientID, testAccOAuthClientSecret),
+				Check: resource.TestCheckResourceAttrSet("data.shopify_function.test", "id"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "shopify" {
+						oauth {
+							client_id     = %q
+							client_secret = "wrong"
+						}
+					}
+
+					data "shopify_function" "test" {
+						title     = "product-discount"
+						app_title = "tf-testing"
+					}
+				`, testAccOAuthClientID),
+				ExpectError: regexp.MustCompile("Failed to Authenticate with Shopify"),
+			},
+		},
+	})
+}
+
 func testAccProviderConfig() string {
 	return fmt.Sprintf(`
 		provider "shopify" {
@@ -48,3 +259,40 @@ func testAccProviderConfig() string {
 		os.Getenv("SHOPIFY_STORE_API_VERSION"),
 	)
 }
+
+func TestConfigure_NewerApiVersion(t *testing.T) {
+	server := newTestAccServer()
+	defer server.Close()
+
+	// Shopify supports 2027-01 a month after its release, but the provider
+	// has not been validated against it.
+	apiVersion := "2027-01"
+	now := time.Date(2027, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	support, err := shopify.CheckApiVersion(apiVersion, now)
+	require.NoError(t, err)
+	require.Equal(t, shopify.ApiVersionUntested, support)
+
+	ctx := context.Background()
+
+	providerServer, err := providerserver.NewProtocol6WithError(&funcProvider{
+		version:       "test",
+		clientOptions: []shopify.Option{shopify.WithHTTPClient(server.Client())},
+		now:           func() time.Time { return now },
+	})()
+	require.NoError(t, err)
+
+	schemas, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	resp, err := providerServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: objectValue(t, schemas.Provider.ValueType(), map[string]tftypes.Value{
+			"store_domain":       tftypes.NewValue(tftypes.String, shopifytest.StoreDomain),
+			"store_access_token": tftypes.NewValue(tftypes.String, shopifytest.StoreAccessToken),
+			"store_api_version":  tftypes.NewValue(tftypes.String, apiVersion),
+		}),
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, resp.Diagnostics)
+}
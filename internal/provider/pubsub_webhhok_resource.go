@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,12 +18,26 @@ import (
 )
 
 var _ resource.Resource = (*pubsubWebhookResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*pubsubWebhookResource)(nil)
+var _ resource.ResourceWithUpgradeState = (*pubsubWebhookResource)(nil)
+var _ resource.ResourceWithMoveState = (*pubsubWebhookResource)(nil)
+var _ resource.ResourceWithIdentity = (*pubsubWebhookResource)(nil)
 
 type pubsubWebhookResource struct {
 	client *shopify.ShopifyAdminClinetImpl
 }
 
 type pubsubWebhookResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Topic         types.String   `tfsdk:"topic"`
+	Format        types.String   `tfsdk:"format"`
+	PubSubProject types.String   `tfsdk:"pubsub_project"`
+	PubSubTopic   types.String   `tfsdk:"pubsub_topic"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// pubsubWebhookResourceModelV0 is state at schema version 0.
+type pubsubWebhookResourceModelV0 struct {
 	ID            types.String `tfsdk:"id"`
 	Topic         types.String `tfsdk:"topic"`
 	Format        types.String `tfsdk:"format"`
@@ -48,6 +64,7 @@ func (r *pubsubWebhookResource) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		Description: "Shopify PubSub Webhook Resource",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -56,7 +73,8 @@ func (r *pubsubWebhookResource) Schema(
 				},
 			},
 			"topic": schema.StringAttribute{
-				Required: true,
+				Description: "The webhook subscription topic, validated against the configured store API version up to the newest version whose topics the provider knows",
+				Required:    true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
@@ -80,6 +98,73 @@ func (r *pubsubWebhookResource) Schema(
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *pubsubWebhookResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("WebhookSubscription")
+}
+
+func (r *pubsubWebhookResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// State written before the timeouts block.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"topic": schema.StringAttribute{
+						Required: true,
+					},
+					"format": schema.StringAttribute{
+						Required: true,
+					},
+					"pubsub_project": schema.StringAttribute{
+						Required: true,
+					},
+					"pubsub_topic": schema.StringAttribute{
+						Required: true,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior pubsubWebhookResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := pubsubWebhookResourceModel{
+					ID:            prior.ID,
+					Topic:         prior.Topic,
+					Format:        prior.Format,
+					PubSubProject: prior.PubSubProject,
+					PubSubTopic:   prior.PubSubTopic,
+					Timeouts:      nullTimeouts(),
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+func (r *pubsubWebhookResource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		compatibleStateMover(r, "shopify_pubsub_webhook", "WebhookSubscription"),
 	}
 }
 
@@ -108,17 +193,85 @@ func (r *pubsubWebhookResource) Configure(
 	r.client = c
 }
 
+func (r *pubsubWebhookResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var topic types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("topic"), &topic)...)
+	if resp.Diagnostics.HasError() || topic.IsUnknown() || topic.IsNull() {
+		return
+	}
+
+	apiVersion := r.client.ApiVersion()
+	t, ok := shopify.WebhookTopics(apiVersion)[topic.ValueString()]
+	switch {
+	case !ok && !shopify.WebhookTopicsKnown(apiVersion):
+		// The provider doesn't ship this version's topics, so Shopify may
+		// have added the topic since. It validates it on apply.
+		versions := shopify.WebhookTopicVersions()
+		detail := fmt.Sprintf(
+			"%q is not a webhook subscription topic the provider knows. It only knows the topics of Shopify API "+
+				"versions up to %s, so Shopify checks the topic against version %s when it is applied.",
+			topic.ValueString(),
+			versions[len(versions)-1],
+			apiVersion,
+		)
+
+		resp.Diagnostics.AddAttributeWarning(path.Root("topic"), "Unknown Webhook Topic", detail+suggestWebhookTopics(apiVersion, topic.ValueString()))
+	case !ok:
+		detail := fmt.Sprintf(
+			"%q is not a webhook subscription topic in Shopify API version %s.",
+			topic.ValueString(),
+			apiVersion,
+		)
+
+		resp.Diagnostics.AddAttributeError(path.Root("topic"), "Invalid Webhook Topic", detail+suggestWebhookTopics(apiVersion, topic.ValueString()))
+		return
+	case t.Deprecated:
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("topic"),
+			"Deprecated Webhook Topic",
+			fmt.Sprintf(
+				"%q is deprecated in Shopify API version %s. %s",
+				t.Name,
+				apiVersion,
+				t.DeprecationReason,
+			),
+		)
+	}
+
+	checkAccessScopes(ctx, r.client, "shopify_pubsub_webhook", shopify.WebhookTopicAccessScopes(topic.ValueString()), &resp.Diagnostics)
+}
+
 func (r *pubsubWebhookResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_pubsub_webhook")
+	defer addDeprecationWarnings(r.client, "shopify_pubsub_webhook", &resp.Diagnostics)
+
 	var data pubsubWebhookResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_pubsub_webhook", "create", createTimeout)
+	defer cancel()
+
 	webhook := &shopify.PubsubWebhook{
 		Topic:         data.Topic.ValueString(),
 		Format:        data.Format.ValueString(),
@@ -128,7 +281,7 @@ func (r *pubsubWebhookResource) Create(
 
 	createdWebhook, err := r.client.PubsubWebhook.Create(ctx, webhook)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create shopify pubsub webhook", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to create shopify pubsub webhook", err)
 		return
 	}
 
@@ -139,6 +292,7 @@ func (r *pubsubWebhookResource) Create(
 	data.PubSubTopic = types.StringValue(createdWebhook.PubSubTopic)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *pubsubWebhookResource) Read(
@@ -146,12 +300,29 @@ func (r *pubsubWebhookResource) Read(
 	req resource.ReadRequest,
 	resp *resource.ReadResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_pubsub_webhook")
+	defer addDeprecationWarnings(r.client, "shopify_pubsub_webhook", &resp.Diagnostics)
+
 	var data pubsubWebhookResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_pubsub_webhook", "read", readTimeout)
+	defer cancel()
 
 	webhook, err := r.client.PubsubWebhook.Get(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get shopify pubsub webhook", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to get shopify pubsub webhook", err)
 		return
 	}
 
@@ -169,12 +340,24 @@ func (r *pubsubWebhookResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_pubsub_webhook")
+	defer addDeprecationWarnings(r.client, "shopify_pubsub_webhook", &resp.Diagnostics)
+
 	var data pubsubWebhookResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, "shopify_pubsub_webhook", "update", updateTimeout)
+	defer cancel()
+
 	webhook := &shopify.PubsubWebhook{
 		ID:            data.ID.ValueString(),
 		Topic:         data.Topic.ValueString(),
@@ -185,7 +368,7 @@ func (r *pubsubWebhookResource) Update(
 
 	updatedWebhook, err := r.client.PubsubWebhook.Update(ctx, webhook)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update shopify pubsub webhook", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to update shopify pubsub webhook", err)
 		return
 	}
 
@@ -196,6 +379,7 @@ func (r *pubsubWebhookResource) Update(
 	data.PubSubTopic = types.StringValue(updatedWebhook.PubSubTopic)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, idIdentityModel{ID: data.ID})...)
 }
 
 func (r *pubsubWebhookResource) Delete(
@@ -203,16 +387,27 @@ func (r *pubsubWebhookResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_pubsub_webhook")
+	defer addDeprecationWarnings(r.client, "shopify_pubsub_webhook", &resp.Diagnostics)
+
 	var data pubsubWebhookResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, "shopify_pubsub_webhook", "delete", deleteTimeout)
+	defer cancel()
+
 	err := r.client.PubsubWebhook.Delete(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete shopify pubsub webhook", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to delete shopify pubsub webhook", err)
 		return
 	}
 }
@@ -222,5 +417,16 @@ func (r *pubsubWebhookResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importID(ctx, "WebhookSubscription", req, resp)
+}
+
+// suggestWebhookTopics returns a sentence suggesting the topics of apiVersion
+// close to topic, or "" if there are none.
+func suggestWebhookTopics(apiVersion string, topic string) string {
+	suggestions := shopify.SuggestWebhookTopics(apiVersion, topic)
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" Did you mean %s?", strings.Join(suggestions, ", "))
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -8,7 +9,7 @@ import (
 
 func TestAccPubsubWebhookResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccPubsubWebhookResourceConfig(),
@@ -58,3 +59,30 @@ func testAccPubsubWebhookResourceConfigUpdate() string {
 		}
 	`
 }
+
+func TestAccPubsubWebhookResource_InvalidTopic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPubsubWebhookResourceConfigInvalidTopic(),
+				ExpectError: regexp.MustCompile(`Did you mean DISCOUNTS_CREATE`),
+			},
+		},
+	})
+}
+
+func testAccPubsubWebhookResourceConfigInvalidTopic() string {
+	return `
+		provider "shopify" {
+			store_api_version = "2024-10"
+		}
+
+		resource "shopify_pubsub_webhook" "test" {
+			topic          = "DISCOUNT_CREATE"
+			format         = "JSON"
+			pubsub_project = "test-project"
+			pubsub_topic   = "test-topic"
+		}
+	`
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ list.ListResource = (*pubsubWebhookListResource)(nil)
+var _ list.ListResourceWithConfigure = (*pubsubWebhookListResource)(nil)
+
+type pubsubWebhookListResource struct {
+	client *shopify.ShopifyAdminClinetImpl
+}
+
+type pubsubWebhookListResourceModel struct {
+	Topics []types.String `tfsdk:"topics"`
+	Format types.String   `tfsdk:"format"`
+}
+
+func NewPubsubWebhookListResource() list.ListResource {
+	return &pubsubWebhookListResource{}
+}
+
+func (r *pubsubWebhookListResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_pubsub_webhook"
+}
+
+func (r *pubsubWebhookListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the store's webhook subscriptions that deliver to Google Cloud Pub/Sub",
+		Attributes: map[string]schema.Attribute{
+			"topics": schema.ListAttribute{
+				Description: "Only list subscriptions to these topics",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"format": schema.StringAttribute{
+				Description: "Only list subscriptions using this payload format",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("JSON", "XML"),
+				},
+			},
+		},
+	}
+}
+
+func (r *pubsubWebhookListResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*shopify.ShopifyAdminClinetImpl)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *shopify.ShopifyAdminClinetImpl, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = c
+}
+
+func (r *pubsubWebhookListResource) List(
+	ctx context.Context,
+	req list.ListRequest,
+	stream *list.ListResultsStream,
+) {
+	var config pubsubWebhookListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &shopify.WebhookSubscriptionFilter{
+		Format: config.Format.ValueString(),
+	}
+
+	for _, topic := range config.Topics {
+		filter.Topics = append(filter.Topics, topic.ValueString())
+	}
+
+	subscriptions, err := r.client.WebhookSubscription.List(ctx, filter)
+	if err != nil {
+		diags.AddError("Failed to list shopify webhook subscriptions", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// HTTP and EventBridge subscriptions aren't managed by shopify_pubsub_webhook.
+	subscriptions = slices.DeleteFunc(subscriptions, func(s shopify.WebhookSubscription) bool {
+		return s.EndpointType != shopify.WebhookEndpointPubSub
+	})
+
+	streamListResults(ctx, req, stream, subscriptions,
+		func(s shopify.WebhookSubscription) string { return s.ID },
+		func(s shopify.WebhookSubscription) string {
+			return fmt.Sprintf("%s to %s/%s", s.Topic, s.PubSubProject, s.PubSubTopic)
+		},
+		func(s shopify.WebhookSubscription) any {
+			return pubsubWebhookResourceModel{
+				ID:            types.StringValue(s.ID),
+				Topic:         types.StringValue(s.Topic),
+				Format:        types.StringValue(s.Format),
+				PubSubProject: types.StringValue(s.PubSubProject),
+				PubSubTopic:   types.StringValue(s.PubSubTopic),
+				Timeouts:      nullTimeouts(),
+			}
+		},
+	)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// planPubsubWebhook plans a new shopify_pubsub_webhook for topic against a
+// fake store on apiVersion, and returns the diagnostics.
+func planPubsubWebhook(t *testing.T, server *shopifytest.Server, apiVersion string, topic string) diag.Diagnostics {
+	t.Helper()
+
+	r := &pubsubWebhookResource{
+		client: shopify.New(
+			shopifytest.StoreDomain,
+			shopifytest.StoreAccessToken,
+			apiVersion,
+			shopify.WithHTTPClient(server.Client()),
+		),
+	}
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	require.False(t, plan.SetAttribute(ctx, path.Root("topic"), topic).HasError())
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, resp)
+
+	return resp.Diagnostics
+}
+
+func TestPubsubWebhookResource_ModifyPlanAccessScopes(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	server.SetAccessScopes("write_discounts")
+
+	// write_discounts also grants read_discounts.
+	assert.Empty(t, planPubsubWebhook(t, server, shopifytest.StoreApiVersion, "DISCOUNTS_CREATE"))
+	assert.Empty(t, planPubsubWebhook(t, server, shopifytest.StoreApiVersion, "APP_UNINSTALLED"))
+
+	diags := planPubsubWebhook(t, server, shopifytest.StoreApiVersion, "ORDERS_CREATE")
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Missing Shopify Access Scopes", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "shopify_pubsub_webhook needs access scopes the app was not granted: read_orders.")
+}
+
+func TestPubsubWebhookResource_ModifyPlanUnknownTopic(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	versions := shopify.WebhookTopicVersions()
+
+	// A topic Shopify added after the newest version the provider knows.
+	const topic = "CHECKOUT_AND_ACCOUNTS_CONFIGURATIONS_UPDATE"
+
+	diags := planPubsubWebhook(t, server, versions[len(versions)-1], topic)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityError, diags[0].Severity())
+	assert.Equal(t, "Invalid Webhook Topic", diags[0].Summary())
+
+	diags = planPubsubWebhook(t, server, "2026-10", topic)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+	assert.Equal(t, "Unknown Webhook Topic", diags[0].Summary())
+
+	diags = planPubsubWebhook(t, server, "2026-10", "DISCOUNT_CREATE")
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Detail(), "Did you mean DISCOUNTS_CREATE")
+}
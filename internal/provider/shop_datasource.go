@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ datasource.DataSource = (*shopDataSource)(nil)
+
+type shopDataSource struct {
+	client *shopify.ShopifyAdminClinetImpl
+}
+
+type shopDataSourceModel struct {
+	ID                           types.String   `tfsdk:"id"`
+	Name                         types.String   `tfsdk:"name"`
+	MyshopifyDomain              types.String   `tfsdk:"myshopify_domain"`
+	PrimaryDomain                types.String   `tfsdk:"primary_domain"`
+	CurrencyCode                 types.String   `tfsdk:"currency_code"`
+	IanaTimezone                 types.String   `tfsdk:"iana_timezone"`
+	Plan                         types.String   `tfsdk:"plan"`
+	EnabledPresentmentCurrencies []types.String `tfsdk:"enabled_presentment_currencies"`
+}
+
+func NewShopDataSource() datasource.DataSource {
+	return &shopDataSource{}
+}
+
+func (d *shopDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_shop"
+}
+
+func (d *shopDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "The shop the provider manages",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The shop GID",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The shop name",
+				Computed:    true,
+			},
+			"myshopify_domain": schema.StringAttribute{
+				Description: "The shop's .myshopify.com domain",
+				Computed:    true,
+			},
+			"primary_domain": schema.StringAttribute{
+				Description: "The host of the shop's primary domain",
+				Computed:    true,
+			},
+			"currency_code": schema.StringAttribute{
+				Description: "The three letter code of the shop's currency, e.g. USD",
+				Computed:    true,
+			},
+			"iana_timezone": schema.StringAttribute{
+				Description: "The shop's IANA timezone, e.g. America/New_York",
+				Computed:    true,
+			},
+			"plan": schema.StringAttribute{
+				Description: "The display name of the shop's Shopify plan",
+				Computed:    true,
+			},
+			"enabled_presentment_currencies": schema.ListAttribute{
+				Description: "The currencies customers can check out in",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *shopDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*shopify.ShopifyAdminClinetImpl)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *shopify.ShopifyAdminClinetImpl, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = c
+}
+
+func (d *shopDataSource) Read(
+	ctx context.Context,
+	_ datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_shop")
+	defer addDeprecationWarnings(d.client, "shopify_shop", &resp.Diagnostics)
+
+	shop, err := d.client.Shop.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get shopify shop", err.Error())
+		return
+	}
+
+	data := shopDataSourceModel{
+		ID:                           types.StringValue(shop.ID),
+		Name:                         types.StringValue(shop.Name),
+		MyshopifyDomain:              types.StringValue(shop.MyshopifyDomain),
+		PrimaryDomain:                types.StringValue(shop.PrimaryDomain),
+		CurrencyCode:                 types.StringValue(shop.CurrencyCode),
+		IanaTimezone:                 types.StringValue(shop.IanaTimezone),
+		Plan:                         types.StringValue(shop.Plan),
+		EnabledPresentmentCurrencies: []types.String{},
+	}
+
+	for _, currency := range shop.EnabledPresentmentCurrencies {
+		data.EnabledPresentmentCurrencies = append(data.EnabledPresentmentCurrencies, types.StringValue(currency))
+	}
+
+	tflog.Trace(ctx, "read a shopify shop data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccShopDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "shopify_shop" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.shopify_shop.test", "id"),
+					resource.TestCheckResourceAttrSet("data.shopify_shop.test", "primary_domain"),
+					resource.TestCheckResourceAttrSet("data.shopify_shop.test", "currency_code"),
+					resource.TestCheckResourceAttrSet("data.shopify_shop.test", "iana_timezone"),
+					resource.TestCheckResourceAttrSet("data.shopify_shop.test", "enabled_presentment_currencies.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeState upgrades the state JSON of typeName written at version through
+// the provider server, as Terraform does after a provider upgrade.
+func upgradeState(t *testing.T, r resource.Resource, typeName string, version int64, json string) map[string]tftypes.Value {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(&funcProvider{version: "test"})()
+	require.NoError(t, err)
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(json)},
+	})
+
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	upgraded, err := resp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	require.NoError(t, err)
+
+	var attributes map[string]tftypes.Value
+	require.NoError(t, upgraded.As(&attributes))
+
+	return attributes
+}
+
+// TestResourcesUpgradeState checks that every resource can upgrade state
+// from each of its earlier schema versions.
+func TestResourcesUpgradeState(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newResource := range (&funcProvider{}).Resources(ctx) {
+		r := newResource()
+
+		var metadataResp resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "shopify"}, &metadataResp)
+
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+		upgrader, ok := r.(resource.ResourceWithUpgradeState)
+		if !assert.True(t, ok, "%s does not upgrade state", metadataResp.TypeName) {
+			continue
+		}
+
+		upgraders := upgrader.UpgradeState(ctx)
+		for version := int64(0); version < schemaResp.Schema.Version; version++ {
+			assert.Contains(t, upgraders, version, "%s has no upgrader from version %d", metadataResp.TypeName, version)
+		}
+	}
+}
+
+func TestUpgradeState_Payment(t *testing.T) {
+	state := upgradeState(t, NewPaymentCustomResource(), "shopify_payment", 0, `{
+		"id": "gid://shopify/PaymentCustomization/1",
+		"function_id": "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		"title": "test_payment",
+		"enabled": true
+	}`)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "gid://shopify/PaymentCustomization/1"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "f2e906be-a93a-48c6-a2cc-99c64e5ab816"), state["function_id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "test_payment"), state["title"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, true), state["enabled"])
+	assert.True(t, state["function_handle"].IsNull())
+	assert.True(t, state["timeouts"].IsNull())
+}
+
+func TestUpgradeState_Delivery(t *testing.T) {
+	state := upgradeState(t, NewDeliveryCustomResource(), "shopify_delivery", 0, `{
+		"id": "gid://shopify/DeliveryCustomization/1",
+		"function_id": "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740",
+		"title": "test_delivery",
+		"enabled": false
+	}`)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "gid://shopify/DeliveryCustomization/1"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "3a2c6a43-6ac1-4d4d-bbd9-59286cc33740"), state["function_id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "test_delivery"), state["title"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, false), state["enabled"])
+	assert.True(t, state["function_handle"].IsNull())
+	assert.True(t, state["timeouts"].IsNull())
+}
+
+func TestUpgradeState_Discount(t *testing.T) {
+	state := upgradeState(t, NewDiscountAutomaticResource(), "shopify_discount", 0, `{
+		"id": "gid://shopify/DiscountAutomaticNode/1",
+		"function_id": "07224386-3c16-4f9e-b8ba-da049b6afc66",
+		"title": "test_discount",
+		"starts_at": "2024-01-01T00:00:00Z",
+		"ends_at": null,
+		"combines_with": {
+			"order_discounts": true,
+			"product_discounts": false,
+			"shipping_discounts": true
+		}
+	}`)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "gid://shopify/DiscountAutomaticNode/1"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "07224386-3c16-4f9e-b8ba-da049b6afc66"), state["function_id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"), state["starts_at"])
+	assert.True(t, state["ends_at"].IsNull())
+	assert.True(t, state["function_handle"].IsNull())
+	assert.True(t, state["timeouts"].IsNull())
+
+	var combinesWith map[string]tftypes.Value
+	require.NoError(t, state["combines_with"].As(&combinesWith))
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, true), combinesWith["order_discounts"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, false), combinesWith["product_discounts"])
+	assert.Equal(t, tftypes.NewValue(tftypes.Bool, true), combinesWith["shipping_discounts"])
+}
+
+func TestUpgradeState_PubsubWebhook(t *testing.T) {
+	state := upgradeState(t, NewPubsubWebhookResource(), "shopify_pubsub_webhook", 0, `{
+		"id": "gid://shopify/WebhookSubscription/1",
+		"topic": "ORDERS_CREATE",
+		"format": "JSON",
+		"pubsub_project": "project",
+		"pubsub_topic": "topic"
+	}`)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "gid://shopify/WebhookSubscription/1"), state["id"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "ORDERS_CREATE"), state["topic"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "JSON"), state["format"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "project"), state["pubsub_project"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "topic"), state["pubsub_topic"])
+	assert.True(t, state["timeouts"].IsNull())
+}
+
+func TestUpgradeState_CurrentVersion(t *testing.T) {
+	state := upgradeState(t, NewPaymentCustomResource(), "shopify_payment", 1, `{
+		"id": "gid://shopify/PaymentCustomization/1",
+		"function_id": "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		"function_handle": "payment-customization",
+		"title": "test_payment",
+		"enabled": true,
+		"timeouts": {"create": "10m", "read": null, "update": null, "delete": null}
+	}`)
+
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "payment-customization"), state["function_handle"])
+	assert.False(t, state["timeouts"].IsNull())
+}
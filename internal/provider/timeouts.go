@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// defaultTimeout bounds operations the timeouts block leaves unset. Admin API
+// operations normally take seconds, so this only stops hung requests.
+const defaultTimeout = 5 * time.Minute
+
+type operationKey struct{}
+
+// operation names what a context is bounded for, so that a timeout can be
+// reported against the operation that ran out of time.
+type operation struct {
+	typeName string
+	name     string
+	timeout  time.Duration
+}
+
+// withTimeout bounds ctx for the name operation, such as "create", of
+// typeName.
+func withTimeout(
+	ctx context.Context,
+	typeName string,
+	name string,
+	timeout time.Duration,
+) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, operationKey{}, operation{typeName: typeName, name: name, timeout: timeout})
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// addOperationError reports err under summary, or, when the deadline set by
+// withTimeout passed, that the operation timed out.
+func addOperationError(ctx context.Context, diags *diag.Diagnostics, summary string, err error) {
+	op, ok := ctx.Value(operationKey{}).(operation)
+	if !ok || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	diags.AddError(
+		"Shopify Operation Timed Out",
+		fmt.Sprintf(
+			"%s %s did not finish within %s: %s\n\n"+
+				"If Shopify is slow to respond, raise timeouts.%s on the resource.",
+			op.typeName,
+			op.name,
+			op.timeout,
+			err,
+			op.name,
+		),
+	)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopifytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddOperationError(t *testing.T) {
+	server := shopifytest.NewServer()
+	defer server.Close()
+
+	server.SetLatency(time.Second)
+
+	client := shopify.New(
+		shopifytest.StoreDomain,
+		shopifytest.StoreAccessToken,
+		shopifytest.StoreApiVersion,
+		shopify.WithHTTPClient(server.Client()),
+	)
+
+	ctx, cancel := withTimeout(context.Background(), "shopify_payment", "create", 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Function.List(ctx)
+	require.Error(t, err)
+
+	var diags diag.Diagnostics
+	addOperationError(ctx, &diags, "Failed to create shopify payment", err)
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Shopify Operation Timed Out", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "shopify_payment create did not finish within 10ms")
+	assert.Contains(t, diags[0].Detail(), "raise timeouts.create")
+}
+
+func TestAddOperationError_NotTimedOut(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), "shopify_payment", "create", time.Minute)
+	defer cancel()
+
+	var diags diag.Diagnostics
+	addOperationError(ctx, &diags, "Failed to create shopify payment", errors.New("function not found"))
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Failed to create shopify payment", diags[0].Summary())
+	assert.Equal(t, "function not found", diags[0].Detail())
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+)
+
+var _ datasource.DataSource = (*webhookSubscriptionsDataSource)(nil)
+
+type webhookSubscriptionsDataSource struct {
+	client *shopify.ShopifyAdminClinetImpl
+}
+
+type webhookSubscriptionsDataSourceModel struct {
+	Topics               []types.String                       `tfsdk:"topics"`
+	CallbackURL          types.String                         `tfsdk:"callback_url"`
+	Format               types.String                         `tfsdk:"format"`
+	WebhookSubscriptions []webhookSubscriptionDataSourceModel `tfsdk:"webhook_subscriptions"`
+}
+
+type webhookSubscriptionDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Topic         types.String `tfsdk:"topic"`
+	Format        types.String `tfsdk:"format"`
+	EndpointType  types.String `tfsdk:"endpoint_type"`
+	CallbackURL   types.String `tfsdk:"callback_url"`
+	Arn           types.String `tfsdk:"arn"`
+	PubSubProject types.String `tfsdk:"pubsub_project"`
+	PubSubTopic   types.String `tfsdk:"pubsub_topic"`
+}
+
+func NewWebhookSubscriptionsDataSource() datasource.DataSource {
+	return &webhookSubscriptionsDataSource{}
+}
+
+func (d *webhookSubscriptionsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_subscriptions"
+}
+
+func (d *webhookSubscriptionsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Shopify Webhook Subscriptions Data Source",
+		Attributes: map[string]schema.Attribute{
+			"topics": schema.ListAttribute{
+				Description: "Only return subscriptions to these topics",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"callback_url": schema.StringAttribute{
+				Description: "Only return HTTP subscriptions delivering to this URL",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"format": schema.StringAttribute{
+				Description: "Only return subscriptions using this payload format",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("JSON", "XML"),
+				},
+			},
+			"webhook_subscriptions": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"topic": schema.StringAttribute{
+							Computed: true,
+						},
+						"format": schema.StringAttribute{
+							Computed: true,
+						},
+						"endpoint_type": schema.StringAttribute{
+							Description: "One of HTTP, EVENT_BRIDGE or PUBSUB",
+							Computed:    true,
+						},
+						"callback_url": schema.StringAttribute{
+							Computed: true,
+						},
+						"arn": schema.StringAttribute{
+							Computed: true,
+						},
+						"pubsub_project": schema.StringAttribute{
+							Computed: true,
+						},
+						"pubsub_topic": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *webhookSubscriptionsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*shopify.ShopifyAdminClinetImpl)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *shopify.ShopifyAdminClinetImpl, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = c
+}
+
+func (d *webhookSubscriptionsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	ctx = shopify.WithDeprecationScope(ctx, "shopify_webhook_subscriptions")
+	defer addDeprecationWarnings(d.client, "shopify_webhook_subscriptions", &resp.Diagnostics)
+
+	var data webhookSubscriptionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := &shopify.WebhookSubscriptionFilter{
+		CallbackURL: data.CallbackURL.ValueString(),
+		Format:      data.Format.ValueString(),
+	}
+
+	for _, topic := range data.Topics {
+		filter.Topics = append(filter.Topics, topic.ValueString())
+	}
+
+	q, err := d.client.WebhookSubscription.List(ctx, filter)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list shopify webhook subscriptions", err.Error())
+		return
+	}
+
+	data.WebhookSubscriptions = []webhookSubscriptionDataSourceModel{}
+	for _, s := range q {
+		data.WebhookSubscriptions = append(data.WebhookSubscriptions, webhookSubscriptionDataSourceModel{
+			ID:            types.StringValue(s.ID),
+			Topic:         types.StringValue(s.Topic),
+			Format:        types.StringValue(s.Format),
+			EndpointType:  types.StringValue(s.EndpointType),
+			CallbackURL:   stringValueOrNull(s.CallbackURL),
+			Arn:           stringValueOrNull(s.Arn),
+			PubSubProject: stringValueOrNull(s.PubSubProject),
+			PubSubTopic:   stringValueOrNull(s.PubSubTopic),
+		})
+	}
+
+	tflog.Trace(ctx, "read a shopify webhook subscriptions data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWebhookSubscriptionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebhookSubscriptionsDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.shopify_webhook_subscriptions.test", "webhook_subscriptions.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.shopify_webhook_subscriptions.test", "webhook_subscriptions.0.id",
+						"shopify_pubsub_webhook.test", "id",
+					),
+					resource.TestCheckResourceAttr("data.shopify_webhook_subscriptions.test", "webhook_subscriptions.0.endpoint_type", "PUBSUB"),
+					resource.TestCheckResourceAttr("data.shopify_webhook_subscriptions.test", "webhook_subscriptions.0.pubsub_project", "test-project"),
+					resource.TestCheckResourceAttr("data.shopify_webhook_subscriptions.test", "webhook_subscriptions.0.pubsub_topic", "test-topic"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWebhookSubscriptionsDataSourceConfig() string {
+	return `
+		resource "shopify_pubsub_webhook" "test" {
+			topic          = "DISCOUNTS_DELETE"
+			format         = "JSON"
+			pubsub_project = "test-project"
+			pubsub_topic   = "test-topic"
+		}
+
+		data "shopify_webhook_subscriptions" "test" {
+			topics = [shopify_pubsub_webhook.test.topic]
+			format = "JSON"
+		}
+	`
+}
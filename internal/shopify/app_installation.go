@@ -0,0 +1,103 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+var _ appInstallationService = (*appInstallationServiceImpl)(nil)
+
+type appInstallationService interface {
+	Get(ctx context.Context) (*AppInstallation, error)
+}
+
+type appInstallationServiceImpl struct {
+	client shopifyAdminClient
+}
+
+type AppInstallation struct {
+	ID           string
+	AppTitle     string
+	AccessScopes []string
+}
+
+func (a *appInstallationServiceImpl) Get(ctx context.Context) (*AppInstallation, error) {
+	gql := `
+		query {
+			currentAppInstallation {
+				id
+				app {
+					title
+				}
+				accessScopes {
+					handle
+				}
+			}
+		}
+	`
+
+	r, err := a.client.exec(ctx, gql)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonb, _ := json.Marshal(r)
+	json := gjson.Parse(string(jsonb)).Get("currentAppInstallation")
+
+	n := &AppInstallation{
+		ID:           json.Get("id").String(),
+		AppTitle:     json.Get("app.title").String(),
+		AccessScopes: []string{},
+	}
+
+	json.Get("accessScopes.#.handle").ForEach(func(_, value gjson.Result) bool {
+		n.AccessScopes = append(n.AccessScopes, value.String())
+		return true
+	})
+
+	return n, nil
+}
+
+// CurrentAppInstallation returns the app installation the client
+// authenticates as. It is fetched once and cached; errors are not cached.
+func (s *ShopifyAdminClinetImpl) CurrentAppInstallation(ctx context.Context) (*AppInstallation, error) {
+	s.appInstallationMu.Lock()
+	defer s.appInstallationMu.Unlock()
+
+	if s.appInstallation != nil {
+		return s.appInstallation, nil
+	}
+
+	installation, err := s.AppInstallation.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.appInstallation = installation
+	return installation, nil
+}
+
+// MissingAccessScopes returns the scopes in required that installation was
+// not granted. A write scope also grants the matching read scope.
+func (a *AppInstallation) MissingAccessScopes(required ...string) []string {
+	granted := map[string]bool{}
+	for _, scope := range a.AccessScopes {
+		granted[scope] = true
+
+		if name, ok := strings.CutPrefix(scope, "write_"); ok {
+			granted["read_"+name] = true
+		}
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,82 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAppInstallationService_Get(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &appInstallationServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	expectedResponse := map[string]interface{}{
+		"currentAppInstallation": map[string]interface{}{
+			"id": "gid://shopify/AppInstallation/1",
+			"app": map[string]interface{}{
+				"title": "tf-testing",
+			},
+			"accessScopes": []interface{}{
+				map[string]interface{}{"handle": "write_discounts"},
+				map[string]interface{}{"handle": "read_payment_customizations"},
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(expectedResponse, nil)
+
+	installation, err := service.Get(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &AppInstallation{
+		ID:           "gid://shopify/AppInstallation/1",
+		AppTitle:     "tf-testing",
+		AccessScopes: []string{"write_discounts", "read_payment_customizations"},
+	}, installation)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCurrentAppInstallation(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	client := &ShopifyAdminClinetImpl{}
+	client.AppInstallation = &appInstallationServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(nil, errors.New("connection refused")).Once()
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(map[string]interface{}{
+		"currentAppInstallation": map[string]interface{}{
+			"id": "gid://shopify/AppInstallation/1",
+		},
+	}, nil).Once()
+
+	_, err := client.CurrentAppInstallation(ctx)
+	assert.Error(t, err)
+
+	for i := 0; i < 2; i++ {
+		installation, err := client.CurrentAppInstallation(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gid://shopify/AppInstallation/1", installation.ID)
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestMissingAccessScopes(t *testing.T) {
+	installation := &AppInstallation{
+		AccessScopes: []string{"write_discounts", "read_payment_customizations"},
+	}
+
+	assert.Empty(t, installation.MissingAccessScopes("write_discounts", "read_discounts", "read_payment_customizations"))
+	assert.Equal(t,
+		[]string{"write_payment_customizations", "write_delivery_customizations"},
+		installation.MissingAccessScopes("write_payment_customizations", "write_delivery_customizations"),
+	)
+}
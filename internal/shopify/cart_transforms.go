@@ -0,0 +1,55 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/tidwall/gjson"
+)
+
+var _ cartTransformService = (*cartTransformServiceImpl)(nil)
+
+type cartTransformService interface {
+	List(ctx context.Context) ([]CartTransform, error)
+}
+
+type cartTransformServiceImpl struct {
+	client shopifyAdminClient
+}
+
+// CartTransform is a cart transform, backed by a function that implements
+// the Cart Transform Function API. Unlike customizations, it has no title.
+type CartTransform struct {
+	ID         string
+	FunctionID string
+}
+
+func (c *cartTransformServiceImpl) List(ctx context.Context) ([]CartTransform, error) {
+	gql := `
+		query {
+			cartTransforms(first: 250%s) {
+				nodes {
+					id
+					functionId
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	cartTransforms := []CartTransform{}
+	err := listConnection(ctx, c.client, gql, "cartTransforms", "", func(node gjson.Result) {
+		cartTransforms = append(cartTransforms, CartTransform{
+			ID:         node.Get("id").String(),
+			FunctionID: node.Get("functionId").String(),
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cartTransforms, nil
+}
@@ -0,0 +1,44 @@
+package shopify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCartTransformService_List(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &cartTransformServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	expectedResponse := map[string]interface{}{
+		"cartTransforms": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":         "gid://shopify/CartTransform/1",
+					"functionId": "6b0e7b3c-5d1e-4f0a-8c0e-2d9b7d1f3a22",
+				},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(expectedResponse, nil)
+
+	cartTransforms, err := service.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []CartTransform{
+		{
+			ID:         "gid://shopify/CartTransform/1",
+			FunctionID: "6b0e7b3c-5d1e-4f0a-8c0e-2d9b7d1f3a22",
+		},
+	}, cartTransforms)
+
+	mockClient.AssertExpectations(t)
+}
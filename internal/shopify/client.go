@@ -3,6 +3,9 @@ package shopify
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/machinebox/graphql"
 )
@@ -12,27 +15,70 @@ type ShopifyAdminClinetImpl struct {
 	storeAccessToken string
 	storeApiVersion  string
 	local            bool
+	httpClient       *http.Client
+	maxIdleConns     int
+	coalesceWindow   time.Duration
+	oauth            *OAuth
 
-	Discount      discountService
-	Payment       paymentService
-	Function      FunctionService
-	Delivery      deliveryService
-	PubsubWebhook pubsubWebhookService
+	disableFunctionCache bool
+	functionCache        *cachedFunctionService
+
+	clientOnce       sync.Once
+	client           *graphql.Client
+	pooledHTTPClient *http.Client
+
+	tokenMu sync.Mutex
+	token   *accessToken
+
+	appInstallationMu sync.Mutex
+	appInstallation   *AppInstallation
+
+	deprecationsMu       sync.Mutex
+	deprecations         []deprecationKey
+	reportedDeprecations map[deprecationKey]bool
+
+	Discount            discountService
+	Payment             paymentService
+	Function            FunctionService
+	Delivery            deliveryService
+	PubsubWebhook       pubsubWebhookService
+	WebhookSubscription webhookSubscriptionService
+	AppInstallation     appInstallationService
+	Shop                shopService
+	Validation          validationService
+	CartTransform       cartTransformService
+}
+
+type Option func(*ShopifyAdminClinetImpl)
+
+// WithHTTPClient sends every operation through httpClient instead of a client
+// with its own connection pool.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *ShopifyAdminClinetImpl) {
+		c.httpClient = httpClient
+	}
 }
 
 type shopifyAdminClient interface {
 	exec(ctx context.Context, query string) (any, error)
+	ApiVersion() string
 }
 
 func New(
 	storeDomain string,
 	storeAccessToken string,
 	storeApiVersion string,
+	opts ...Option,
 ) *ShopifyAdminClinetImpl {
 	c := &ShopifyAdminClinetImpl{
 		storeDomain:      storeDomain,
 		storeAccessToken: storeAccessToken,
 		storeApiVersion:  storeApiVersion,
+		coalesceWindow:   DefaultCoalesceWindow,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
 
 	c.Discount = &discountServiceImpl{c}
@@ -40,28 +86,143 @@ func New(
 	c.Payment = &paymentServiceImpl{c}
 	c.Delivery = &deliveryServiceImpl{c}
 	c.PubsubWebhook = &pubsubWebhookServiceImpl{c}
+	c.WebhookSubscription = &webhookSubscriptionServiceImpl{c}
+	c.AppInstallation = &appInstallationServiceImpl{c}
+	c.Shop = &shopServiceImpl{c}
+	c.Validation = &validationServiceImpl{c}
+	c.CartTransform = &cartTransformServiceImpl{c}
+
+	if !c.disableFunctionCache {
+		c.functionCache = &cachedFunctionService{FunctionService: c.Function, ttl: FunctionCacheTTL}
+		c.Function = c.functionCache
+	}
+
+	if c.coalesceWindow > 0 {
+		nodes := &nodeLoader{client: c, window: c.coalesceWindow, report: c.report}
+
+		c.Discount = &coalescedDiscountService{c.Discount, nodes}
+		c.Payment = &coalescedPaymentService{c.Payment, nodes}
+		c.Delivery = &coalescedDeliveryService{c.Delivery, nodes}
+	}
 
 	return c
 }
 
+func (s *ShopifyAdminClinetImpl) ApiVersion() string {
+	return s.storeApiVersion
+}
+
 func (s *ShopifyAdminClinetImpl) exec(ctx context.Context, query string) (any, error) {
-	scheme := "https"
-	if s.local {
-		scheme = "http"
+	defer s.invalidateFunctionCache(query)
+
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	res, status, err := s.run(ctx, query, token)
+	if status == http.StatusUnauthorized && s.oauth != nil {
+		// The token was revoked or expired early; fetch a new one and retry
+		// once.
+		s.invalidateAccessToken(token)
+
+		if token, err = s.accessToken(ctx); err != nil {
+			return "", err
+		}
+
+		res, _, err = s.run(ctx, query, token)
+	}
+
+	if err != nil {
+		return "", err
 	}
 
-	endpoint := fmt.Sprintf("%s://%s/admin/api/%s/graphql.json", scheme, s.storeDomain, s.storeApiVersion)
-	client := graphql.NewClient(endpoint)
+	return res, nil
+}
+
+// run sends query with token and returns the result along with the HTTP
+// status code of the response.
+func (s *ShopifyAdminClinetImpl) run(ctx context.Context, query string, token string) (any, int, error) {
+	client := s.graphqlClient()
 	req := graphql.NewRequest(query)
 
-	req.Header.Set("X-Shopify-Access-Token", s.storeAccessToken)
+	req.Header.Set("X-Shopify-Access-Token", token)
 	req.Header.Set("Cache-Control", "no-cache")
 
 	var res any
 
-	if err := client.Run(ctx, req, &res); err != nil {
-		return "", err
+	response := &operationResponse{}
+	start := time.Now()
+
+	err := client.Run(withOperationResponse(ctx, response), req, &res)
+
+	report := operationReport{query, token, response, time.Since(start), err}
+	if reports, ok := ctx.Value(operationReportsKey{}).(*[]operationReport); ok {
+		*reports = append(*reports, report)
+	} else {
+		s.report(ctx, report)
 	}
 
-	return res, nil
+	return res, response.statusCode, err
+}
+
+type operationReportsKey struct{}
+
+// operationReport is the outcome of running an operation, which is logged
+// and whose deprecations are recorded with the context of the caller.
+type operationReport struct {
+	query    string
+	token    string
+	response *operationResponse
+	latency  time.Duration
+	err      error
+}
+
+// withOperationReports makes the operations run with ctx append their
+// outcome to reports rather than report it, for operations shared by several
+// callers to report to each of them.
+func withOperationReports(ctx context.Context, reports *[]operationReport) context.Context {
+	return context.WithValue(ctx, operationReportsKey{}, reports)
+}
+
+// report logs the outcome of an operation with ctx and records its
+// deprecations in the deprecation scope of ctx.
+func (s *ShopifyAdminClinetImpl) report(ctx context.Context, r operationReport) {
+	logOperation(logContext(ctx, r.token), r.query, r.token, r.response, r.latency, r.err)
+
+	if len(r.response.deprecations) > 0 {
+		s.recordDeprecations(ctx, operationName(r.query), r.response.deprecations)
+	}
+}
+
+// graphqlClient returns the client every operation is sent with.
+func (s *ShopifyAdminClinetImpl) graphqlClient() *graphql.Client {
+	s.initClients()
+	return s.client
+}
+
+// sharedHTTPClient returns the HTTP client, and so the connection pool, every
+// request to the store is sent with.
+func (s *ShopifyAdminClinetImpl) sharedHTTPClient() *http.Client {
+	s.initClients()
+	return s.pooledHTTPClient
+}
+
+// initClients creates the clients on first use rather than in New, so tests
+// can still switch the client to plain HTTP after constructing it.
+func (s *ShopifyAdminClinetImpl) initClients() {
+	s.clientOnce.Do(func() {
+		endpoint := fmt.Sprintf("%s://%s/admin/api/%s/graphql.json", s.scheme(), s.storeDomain, s.storeApiVersion)
+
+		s.pooledHTTPClient = s.newHTTPClient()
+		s.client = graphql.NewClient(endpoint, graphql.WithHTTPClient(s.pooledHTTPClient))
+	})
+}
+
+func (s *ShopifyAdminClinetImpl) scheme() string {
+	if s.local {
+		return "http"
+	}
+
+	return "https"
 }
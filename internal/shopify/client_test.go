@@ -12,6 +12,7 @@ import (
 
 type mockShopifyAdminClient struct {
 	mock.Mock
+	apiVersion string
 }
 
 func (m *mockShopifyAdminClient) exec(ctx context.Context, query string) (interface{}, error) {
@@ -19,6 +20,10 @@ func (m *mockShopifyAdminClient) exec(ctx context.Context, query string) (interf
 	return args.Get(0), args.Error(1)
 }
 
+func (m *mockShopifyAdminClient) ApiVersion() string {
+	return m.apiVersion
+}
+
 func TestNew(t *testing.T) {
 	client := New("example.myshopify.com", "access_token", "2023-04")
 
@@ -30,6 +35,9 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, client.Function)
 	assert.NotNil(t, client.Payment)
 	assert.NotNil(t, client.Delivery)
+	assert.NotNil(t, client.WebhookSubscription)
+	assert.NotNil(t, client.AppInstallation)
+	assert.NotNil(t, client.Shop)
 }
 
 func TestExec(t *testing.T) {
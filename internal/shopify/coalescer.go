@@ -0,0 +1,303 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// DefaultCoalesceWindow is how long a Get waits for other Get calls to share
+// its query with. Terraform reads up to 10 resources at once by default, so
+// a refresh sends a tenth of the queries it otherwise would.
+const DefaultCoalesceWindow = 10 * time.Millisecond
+
+// maxNodesPerQuery is the most IDs the Admin API accepts in one nodes query.
+const maxNodesPerQuery = 250
+
+// errBatchFailed wraps the error of a nodes query for more than one ID. The
+// whole query fails when one of its IDs is malformed, so the callers retry on
+// their own to get the error that is theirs.
+var errBatchFailed = errors.New("batched nodes query failed")
+
+// errBatchDeprecated is returned for a nodes query that used deprecated
+// fields of more than one type. Shopify doesn't say which type they belong
+// to, so the callers retry on their own to record only their deprecations.
+var errBatchDeprecated = fmt.Errorf("%w: deprecated fields used", errBatchFailed)
+
+const (
+	paymentCustomizationFragment = `
+		... on PaymentCustomization {
+			id
+			functionId
+			title
+			enabled
+		}
+	`
+
+	deliveryCustomizationFragment = `
+		... on DeliveryCustomization {
+			id
+			functionId
+			title
+			enabled
+		}
+	`
+
+	automaticAppDiscountFragment = `
+		... on DiscountAutomaticNode {
+			automaticDiscount {
+				... on DiscountAutomaticApp {
+					discountId
+					appDiscountType {
+						functionId
+					}
+					title
+					startsAt
+					endsAt
+					combinesWith {
+						orderDiscounts
+						productDiscounts
+						shippingDiscounts
+					}
+				}
+			}
+		}
+	`
+)
+
+// WithReadCoalescing sets how long Get calls for discounts, payment
+// customizations and delivery customizations wait to be sent together in a
+// single nodes query. A window of zero sends every Get on its own.
+func WithReadCoalescing(window time.Duration) Option {
+	return func(c *ShopifyAdminClinetImpl) {
+		c.coalesceWindow = window
+	}
+}
+
+// nodeLoader gathers the IDs requested within a window into one nodes query
+// and hands each caller its own node.
+type nodeLoader struct {
+	client shopifyAdminClient
+	window time.Duration
+
+	// report logs and records the deprecations of the nodes queries with
+	// the context of each of their callers. It may be nil.
+	report func(ctx context.Context, r operationReport)
+
+	mu    sync.Mutex
+	batch *nodeBatch
+}
+
+type nodeBatch struct {
+	// ctx is the batch's own context, without the deadline, values or
+	// deprecation scope of any caller. It is canceled once every caller
+	// has given up waiting.
+	ctx       context.Context
+	cancel    context.CancelFunc
+	waiting   int
+	ids       []string
+	index     map[string]int
+	fragments map[string]bool
+
+	done    chan struct{}
+	nodes   []gjson.Result
+	err     error
+	reports []operationReport
+}
+
+// load returns the node with id, selected with fragment. A node that does not
+// exist is returned as a gjson.Result that does not exist.
+func (l *nodeLoader) load(ctx context.Context, id string, fragment string) (gjson.Result, error) {
+	l.mu.Lock()
+
+	b := l.batch
+	if b == nil {
+		b = &nodeBatch{
+			index:     map[string]int{},
+			fragments: map[string]bool{},
+			done:      make(chan struct{}),
+		}
+
+		b.ctx, b.cancel = context.WithCancel(withOperationReports(context.Background(), &b.reports))
+
+		l.batch = b
+		time.AfterFunc(l.window, func() { l.flush(b) })
+	}
+
+	i, ok := b.index[id]
+	if !ok {
+		i = len(b.ids)
+		b.index[id] = i
+		b.ids = append(b.ids, id)
+	}
+
+	b.fragments[fragment] = true
+	b.waiting++
+
+	if len(b.ids) >= maxNodesPerQuery {
+		l.batch = nil
+		go b.run(l.client)
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		l.mu.Lock()
+		if b.waiting--; b.waiting == 0 {
+			b.cancel()
+		}
+		l.mu.Unlock()
+
+		return gjson.Result{}, ctx.Err()
+	}
+
+	deprecated := false
+	for _, r := range b.reports {
+		if len(r.response.deprecations) > 0 && len(b.fragments) > 1 {
+			deprecated = true
+
+			response := *r.response
+			response.deprecations = nil
+			r.response = &response
+		}
+
+		if l.report != nil {
+			l.report(ctx, r)
+		}
+	}
+
+	if b.err != nil {
+		return gjson.Result{}, b.err
+	}
+
+	if deprecated {
+		return gjson.Result{}, errBatchDeprecated
+	}
+
+	return b.nodes[i], nil
+}
+
+// flush sends b unless it already filled up and was sent.
+func (l *nodeLoader) flush(b *nodeBatch) {
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+
+	l.batch = nil
+	l.mu.Unlock()
+
+	b.run(l.client)
+}
+
+func (b *nodeBatch) run(client shopifyAdminClient) {
+	defer close(b.done)
+	defer b.cancel()
+
+	r, err := client.exec(b.ctx, nodesQuery(b.ids, b.fragments))
+	if err != nil {
+		b.err = err
+		if len(b.ids) > 1 {
+			b.err = fmt.Errorf("%w: %w", errBatchFailed, err)
+		}
+
+		return
+	}
+
+	jsonb, _ := json.Marshal(r)
+	nodes := gjson.Parse(string(jsonb)).Get("nodes").Array()
+
+	b.nodes = make([]gjson.Result, len(b.ids))
+	copy(b.nodes, nodes)
+}
+
+// nodesQuery selects ids with every fragment. Fragments are sorted so that
+// the same batch always sends the same query.
+func nodesQuery(ids []string, fragments map[string]bool) string {
+	quoted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		quoted = append(quoted, fmt.Sprintf("%q", id))
+	}
+
+	selections := make([]string, 0, len(fragments))
+	for fragment := range fragments {
+		selections = append(selections, fragment)
+	}
+
+	sort.Strings(selections)
+
+	gql := `
+		query {
+			nodes(ids: [%s]) {
+				id
+				%s
+			}
+		}
+	`
+
+	return fmt.Sprintf(gql, strings.Join(quoted, ", "), strings.Join(selections, "\n"))
+}
+
+type coalescedDiscountService struct {
+	discountService
+	nodes *nodeLoader
+}
+
+func (d *coalescedDiscountService) Get(ctx context.Context, discountID string) (*DiscountNode, error) {
+	node, err := d.nodes.load(ctx, discountID, automaticAppDiscountFragment)
+	if errors.Is(err, errBatchFailed) {
+		return d.discountService.Get(ctx, discountID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return automaticAppDiscountNode(node.Get("automaticDiscount")), nil
+}
+
+type coalescedPaymentService struct {
+	paymentService
+	nodes *nodeLoader
+}
+
+func (p *coalescedPaymentService) Get(ctx context.Context, paymentID string) (*PaymentNode, error) {
+	node, err := p.nodes.load(ctx, paymentID, paymentCustomizationFragment)
+	if errors.Is(err, errBatchFailed) {
+		return p.paymentService.Get(ctx, paymentID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentCustomizationNode(node), nil
+}
+
+type coalescedDeliveryService struct {
+	deliveryService
+	nodes *nodeLoader
+}
+
+func (d *coalescedDeliveryService) Get(ctx context.Context, deliveryID string) (*DeliveryNode, error) {
+	node, err := d.nodes.load(ctx, deliveryID, deliveryCustomizationFragment)
+	if errors.Is(err, errBatchFailed) {
+		return d.deliveryService.Get(ctx, deliveryID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveryCustomizationNode(node), nil
+}
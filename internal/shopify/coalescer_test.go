@@ -0,0 +1,384 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// nodesClient answers nodes queries for the IDs it knows and records every
+// query it is sent.
+type nodesClient struct {
+	mu      sync.Mutex
+	queries []string
+	fail    func(ids []string) error
+}
+
+func (c *nodesClient) exec(_ context.Context, query string) (any, error) {
+	c.mu.Lock()
+	c.queries = append(c.queries, query)
+	c.mu.Unlock()
+
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return nil, err
+	}
+
+	field := doc.Operations[0].SelectionSet[0].(*ast.Field)
+	if field.Name != "nodes" {
+		// An uncoalesced Get.
+		id := field.Arguments.ForName("id").Value.Raw
+		if c.fail != nil {
+			if err := c.fail([]string{id}); err != nil {
+				return nil, err
+			}
+		}
+
+		return map[string]any{field.Name: testNode(id)}, nil
+	}
+
+	var ids []string
+	for _, child := range field.Arguments.ForName("ids").Value.Children {
+		ids = append(ids, child.Value.Raw)
+	}
+
+	if c.fail != nil {
+		if err := c.fail(ids); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := []any{}
+	for _, id := range ids {
+		nodes = append(nodes, testNode(id))
+	}
+
+	return map[string]any{"nodes": nodes}, nil
+}
+
+func (c *nodesClient) ApiVersion() string {
+	return "2024-07"
+}
+
+func (c *nodesClient) queryCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.queries)
+}
+
+func testNode(id string) any {
+	switch {
+	case strings.HasPrefix(id, "gid://shopify/DiscountAutomaticNode/"):
+		discount := map[string]any{
+			"discountId": id,
+			"title":      "discount " + id,
+			"startsAt":   "2024-01-01T00:00:00Z",
+		}
+
+		// Shaped like the result of the nodes query, and of discountNode.
+		return map[string]any{"id": id, "automaticDiscount": discount, "discount": discount}
+	case strings.HasPrefix(id, "gid://shopify/PaymentCustomization/"),
+		strings.HasPrefix(id, "gid://shopify/DeliveryCustomization/"):
+		return map[string]any{
+			"id":         id,
+			"functionId": "function",
+			"title":      "customization " + id,
+			"enabled":    true,
+		}
+	default:
+		return nil
+	}
+}
+
+func newCoalescingTestClient(client shopifyAdminClient) *ShopifyAdminClinetImpl {
+	nodes := &nodeLoader{client: client, window: 20 * time.Millisecond}
+
+	return &ShopifyAdminClinetImpl{
+		Discount: &coalescedDiscountService{&discountServiceImpl{client}, nodes},
+		Payment:  &coalescedPaymentService{&paymentServiceImpl{client}, nodes},
+		Delivery: &coalescedDeliveryService{&deliveryServiceImpl{client}, nodes},
+	}
+}
+
+func TestCoalescedGet(t *testing.T) {
+	client := &nodesClient{}
+	c := newCoalescingTestClient(client)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+
+			id := fmt.Sprintf("gid://shopify/PaymentCustomization/%d", i)
+			payment, err := c.Payment.Get(ctx, id)
+
+			assert.NoError(t, err)
+			assert.Equal(t, &PaymentNode{ID: id, FunctionID: "function", Title: "customization " + id, Enabled: true}, payment)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			id := fmt.Sprintf("gid://shopify/DeliveryCustomization/%d", i)
+			delivery, err := c.Delivery.Get(ctx, id)
+
+			assert.NoError(t, err)
+			assert.Equal(t, id, delivery.ID)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			id := fmt.Sprintf("gid://shopify/DiscountAutomaticNode/%d", i)
+			discount, err := c.Discount.Get(ctx, id)
+
+			assert.NoError(t, err)
+			assert.Equal(t, id, discount.ID)
+			assert.Equal(t, "discount "+id, discount.Title)
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, 1, client.queryCount())
+	assert.Contains(t, client.queries[0], "... on PaymentCustomization")
+	assert.Contains(t, client.queries[0], "... on DeliveryCustomization")
+	assert.Contains(t, client.queries[0], "... on DiscountAutomaticNode")
+}
+
+func TestCoalescedGet_Missing(t *testing.T) {
+	c := newCoalescingTestClient(&nodesClient{})
+
+	payment, err := c.Payment.Get(context.Background(), "gid://shopify/Missing/1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "", payment.ID)
+}
+
+func TestCoalescedGet_BatchFailure(t *testing.T) {
+	client := &nodesClient{
+		fail: func(ids []string) error {
+			for _, id := range ids {
+				if id == "invalid" {
+					return errors.New("invalid global id 'invalid'")
+				}
+			}
+
+			return nil
+		},
+	}
+
+	c := newCoalescingTestClient(client)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		payment, err := c.Payment.Get(ctx, "gid://shopify/PaymentCustomization/1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gid://shopify/PaymentCustomization/1", payment.ID)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		_, err := c.Payment.Get(ctx, "invalid")
+
+		assert.EqualError(t, err, "invalid global id 'invalid'")
+	}()
+
+	wg.Wait()
+
+	// The batch, then each ID on its own.
+	assert.Equal(t, 3, client.queryCount())
+}
+
+func TestCoalescedGet_Cancelled(t *testing.T) {
+	client := &nodesClient{}
+	c := newCoalescingTestClient(client)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		_, err := c.Payment.Get(cancelled, "gid://shopify/PaymentCustomization/1")
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		// Join the batch the cancelled call started.
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+
+		payment, err := c.Payment.Get(context.Background(), "gid://shopify/PaymentCustomization/2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gid://shopify/PaymentCustomization/2", payment.ID)
+	}()
+
+	wg.Wait()
+}
+
+func TestCoalescedGet_FullBatch(t *testing.T) {
+	client := &nodesClient{}
+	nodes := &nodeLoader{client: client, window: time.Hour}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2*maxNodesPerQuery; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			id := fmt.Sprintf("gid://shopify/PaymentCustomization/%d", i)
+			node, err := nodes.load(context.Background(), id, paymentCustomizationFragment)
+
+			assert.NoError(t, err)
+			assert.Equal(t, id, node.Get("id").String())
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 2, client.queryCount())
+}
+
+func TestCoalescedGet_Deprecations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		data, err := (&nodesClient{}).exec(r.Context(), body.Query)
+		require.NoError(t, err)
+
+		// Only delivery customizations use a deprecated field.
+		if strings.Contains(strings.ToLower(body.Query), "deliverycustomization") {
+			w.Header().Set(DeprecatedReasonHeader, "DeliveryCustomization is deprecated")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+	}))
+
+	defer server.Close()
+
+	client := New(server.URL[7:], "access_token", "2024-07", WithHTTPClient(server.Client()), WithReadCoalescing(50*time.Millisecond))
+	client.local = true
+
+	get := func(scope string, id string) {
+		ctx := WithDeprecationScope(context.Background(), scope)
+
+		var err error
+		if strings.Contains(id, "Delivery") {
+			_, err = client.Delivery.Get(ctx, id)
+		} else {
+			_, err = client.Payment.Get(ctx, id)
+		}
+
+		assert.NoError(t, err)
+	}
+
+	t.Run("Every caller records the batch's deprecations", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			get("first", "gid://shopify/DeliveryCustomization/1")
+		}()
+
+		go func() {
+			defer wg.Done()
+			get("second", "gid://shopify/DeliveryCustomization/2")
+		}()
+
+		wg.Wait()
+
+		deprecation := []Deprecation{{Operation: "nodes", Reason: "DeliveryCustomization is deprecated"}}
+		assert.Equal(t, deprecation, client.TakeDeprecations("first"))
+		assert.Equal(t, deprecation, client.TakeDeprecations("second"))
+		assert.Empty(t, client.TakeDeprecations(""))
+	})
+
+	t.Run("Callers of other types record none of them", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			get("shopify_payment", "gid://shopify/PaymentCustomization/1")
+		}()
+
+		go func() {
+			defer wg.Done()
+			get("shopify_delivery", "gid://shopify/DeliveryCustomization/1")
+		}()
+
+		wg.Wait()
+
+		assert.Empty(t, client.TakeDeprecations("shopify_payment"))
+		assert.Equal(t, []Deprecation{
+			{Operation: "deliveryCustomization", Reason: "DeliveryCustomization is deprecated"},
+		}, client.TakeDeprecations("shopify_delivery"))
+	})
+}
+
+// contextClient records the contexts it runs operations with.
+type contextClient struct {
+	nodesClient
+	contexts []context.Context
+}
+
+func (c *contextClient) exec(ctx context.Context, query string) (any, error) {
+	c.mu.Lock()
+	c.contexts = append(c.contexts, ctx)
+	c.mu.Unlock()
+
+	return c.nodesClient.exec(ctx, query)
+}
+
+func TestCoalescedGet_BatchContext(t *testing.T) {
+	client := &contextClient{}
+	nodes := &nodeLoader{client: client, window: 20 * time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(WithDeprecationScope(context.Background(), "shopify_payment"), time.Hour)
+	defer cancel()
+
+	_, err := nodes.load(ctx, "gid://shopify/PaymentCustomization/1", paymentCustomizationFragment)
+	require.NoError(t, err)
+
+	require.Len(t, client.contexts, 1)
+
+	_, ok := client.contexts[0].Deadline()
+	assert.False(t, ok)
+	assert.Nil(t, client.contexts[0].Value(deprecationScopeKey{}))
+}
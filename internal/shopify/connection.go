@@ -0,0 +1,84 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// CustomizationFilter narrows a listing of payment or delivery
+// customizations. Zero fields don't filter.
+type CustomizationFilter struct {
+	FunctionID string
+	Enabled    *bool
+}
+
+// search returns the filter as an Admin API search query.
+func (f *CustomizationFilter) search() string {
+	if f == nil {
+		return ""
+	}
+
+	var terms []string
+	if f.FunctionID != "" {
+		terms = append(terms, "function_id:"+f.FunctionID)
+	}
+
+	if f.Enabled != nil {
+		terms = append(terms, fmt.Sprintf("enabled:%t", *f.Enabled))
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// searchArgs returns the connection arguments that filter it with the search
+// query search, if any.
+func searchArgs(search string) string {
+	if search == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`, query: "%s"`, search)
+}
+
+// listConnection pages through the connection field that gql selects,
+// formatting gql with args, the connection's arguments after first, and the
+// page's cursor, and calls visit with every node of every page.
+func listConnection(
+	ctx context.Context,
+	client shopifyAdminClient,
+	gql string,
+	field string,
+	args string,
+	visit func(node gjson.Result),
+) error {
+	cursor := ""
+	for {
+		pageArgs := args
+		if cursor != "" {
+			pageArgs += fmt.Sprintf(`, after: "%s"`, cursor)
+		}
+
+		r, err := client.exec(ctx, fmt.Sprintf(gql, pageArgs))
+		if err != nil {
+			return err
+		}
+
+		jsonb, _ := json.Marshal(r)
+		json := gjson.Parse(string(jsonb)).Get(field)
+
+		json.Get("nodes").ForEach(func(_, value gjson.Result) bool {
+			visit(value)
+			return true
+		})
+
+		if !json.Get("pageInfo.hasNextPage").Bool() {
+			return nil
+		}
+
+		cursor = json.Get("pageInfo.endCursor").String()
+	}
+}
@@ -12,6 +12,7 @@ var _ deliveryService = (*deliveryServiceImpl)(nil)
 
 type deliveryService interface {
 	Get(ctx context.Context, deliveryID string) (*DeliveryNode, error)
+	List(ctx context.Context, filter *CustomizationFilter) ([]DeliveryNode, error)
 	Create(ctx context.Context, functionID string, delivery *DeliveryNode) (*DeliveryNode, error)
 	Update(ctx context.Context, delivery *DeliveryNode) (*DeliveryNode, error)
 	Delete(ctx context.Context, deliveryID string) (*DeliveryNode, error)
@@ -22,9 +23,14 @@ type deliveryServiceImpl struct {
 }
 
 type DeliveryNode struct {
-	ID      string
-	Title   string
-	Enabled bool
+	ID         string
+	FunctionID string
+	Title      string
+	Enabled    bool
+
+	// FunctionHandle, when set, is sent instead of the function ID on
+	// creation by API versions that accept it.
+	FunctionHandle string
 }
 
 func (d *deliveryServiceImpl) Get(ctx context.Context, deliveryID string) (*DeliveryNode, error) {
@@ -32,6 +38,7 @@ func (d *deliveryServiceImpl) Get(ctx context.Context, deliveryID string) (*Deli
 		query {
 			deliveryCustomization(id: "%s") {
 				id
+				functionId
 				title
 				enabled
 			}
@@ -45,15 +52,46 @@ func (d *deliveryServiceImpl) Get(ctx context.Context, deliveryID string) (*Deli
 	}
 
 	jsonb, _ := json.Marshal(r)
-	json := gjson.Parse(string(jsonb)).Get("deliveryCustomization")
 
-	n := &DeliveryNode{
-		ID:      json.Get("id").String(),
-		Title:   json.Get("title").String(),
-		Enabled: json.Get("enabled").Bool(),
+	return deliveryCustomizationNode(gjson.Parse(string(jsonb)).Get("deliveryCustomization")), nil
+}
+
+func deliveryCustomizationNode(json gjson.Result) *DeliveryNode {
+	return &DeliveryNode{
+		ID:         json.Get("id").String(),
+		FunctionID: json.Get("functionId").String(),
+		Title:      json.Get("title").String(),
+		Enabled:    json.Get("enabled").Bool(),
 	}
+}
 
-	return n, nil
+// List returns the delivery customizations that match filter, or all of
+// them when filter is nil.
+func (d *deliveryServiceImpl) List(ctx context.Context, filter *CustomizationFilter) ([]DeliveryNode, error) {
+	gql := `
+		query {
+			deliveryCustomizations(first: 250%s) {
+				nodes {
+					` + deliveryCustomizationFragment + `
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	nodes := []DeliveryNode{}
+	err := listConnection(ctx, d.client, gql, "deliveryCustomizations", searchArgs(filter.search()), func(node gjson.Result) {
+		nodes = append(nodes, *deliveryCustomizationNode(node))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
 }
 
 func (d *deliveryServiceImpl) Create(ctx context.Context, functionID string, delivery *DeliveryNode) (*DeliveryNode, error) {
@@ -61,13 +99,14 @@ func (d *deliveryServiceImpl) Create(ctx context.Context, functionID string, del
 		mutation {
 			deliveryCustomizationCreate(
 				deliveryCustomization: {
-					functionId: "%s"
+					%s
 					title: "%s"
 					enabled: %t
 				}
 			) {
 				deliveryCustomization {
 					id
+					functionId
 					title
 					enabled
 				}
@@ -80,7 +119,13 @@ func (d *deliveryServiceImpl) Create(ctx context.Context, functionID string, del
 		}
 	`
 
-	gql = fmt.Sprintf(gql, functionID, delivery.Title, delivery.Enabled)
+	gql = fmt.Sprintf(
+		gql,
+		functionReference(d.client, functionID, delivery.FunctionHandle),
+		delivery.Title,
+		delivery.Enabled,
+	)
+
 	r, err := d.client.exec(ctx, gql)
 	if err != nil {
 		return nil, err
@@ -90,9 +135,10 @@ func (d *deliveryServiceImpl) Create(ctx context.Context, functionID string, del
 	json := gjson.Parse(string(jsonb)).Get("deliveryCustomizationCreate.deliveryCustomization")
 
 	n := &DeliveryNode{
-		ID:      json.Get("id").String(),
-		Title:   json.Get("title").String(),
-		Enabled: json.Get("enabled").Bool(),
+		ID:         json.Get("id").String(),
+		FunctionID: json.Get("functionId").String(),
+		Title:      json.Get("title").String(),
+		Enabled:    json.Get("enabled").Bool(),
 	}
 
 	return n, nil
@@ -110,6 +156,7 @@ func (d *deliveryServiceImpl) Update(ctx context.Context, delivery *DeliveryNode
 			) {
 				deliveryCustomization {
 					id
+					functionId
 					title
 					enabled
 				}
@@ -127,9 +174,10 @@ func (d *deliveryServiceImpl) Update(ctx context.Context, delivery *DeliveryNode
 	json := gjson.Parse(string(jsonb)).Get("deliveryCustomizationUpdate.deliveryCustomization")
 
 	n := &DeliveryNode{
-		ID:      json.Get("id").String(),
-		Title:   json.Get("title").String(),
-		Enabled: json.Get("enabled").Bool(),
+		ID:         json.Get("id").String(),
+		FunctionID: json.Get("functionId").String(),
+		Title:      json.Get("title").String(),
+		Enabled:    json.Get("enabled").Bool(),
 	}
 
 	return n, nil
@@ -2,6 +2,7 @@ package shopify
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,9 +15,10 @@ func TestDeliveryServiceImpl_Get(t *testing.T) {
 
 	mockResponse := map[string]interface{}{
 		"deliveryCustomization": map[string]interface{}{
-			"id":      "gid://shopify/DeliveryCustomization/1",
-			"title":   "Test Delivery",
-			"enabled": true,
+			"id":         "gid://shopify/DeliveryCustomization/1",
+			"functionId": "5c9bd2c9-8a0f-4a3c-9d4e-1f2b3c4d5e6f",
+			"title":      "Test Delivery",
+			"enabled":    true,
 		},
 	}
 
@@ -27,12 +29,53 @@ func TestDeliveryServiceImpl_Get(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "gid://shopify/DeliveryCustomization/1", result.ID)
+	assert.Equal(t, "5c9bd2c9-8a0f-4a3c-9d4e-1f2b3c4d5e6f", result.FunctionID)
 	assert.Equal(t, "Test Delivery", result.Title)
 	assert.True(t, result.Enabled)
 
 	mockClient.AssertExpectations(t)
 }
 
+func TestDeliveryServiceImpl_List(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &deliveryServiceImpl{client: mockClient}
+
+	mockResponse := map[string]interface{}{
+		"deliveryCustomizations": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":         "gid://shopify/DeliveryCustomization/1",
+					"functionId": "5c9bd2c9-8a0f-4a3c-9d4e-1f2b3c4d5e6f",
+					"title":      "Test Delivery",
+					"enabled":    true,
+				},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	mockClient.On("exec", mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, `query: "enabled:true"`)
+	})).Return(mockResponse, nil)
+
+	enabled := true
+	result, err := service.List(context.Background(), &CustomizationFilter{Enabled: &enabled})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []DeliveryNode{
+		{
+			ID:         "gid://shopify/DeliveryCustomization/1",
+			FunctionID: "5c9bd2c9-8a0f-4a3c-9d4e-1f2b3c4d5e6f",
+			Title:      "Test Delivery",
+			Enabled:    true,
+		},
+	}, result)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestDeliveryServiceImpl_Create(t *testing.T) {
 	mockClient := new(mockShopifyAdminClient)
 	service := &deliveryServiceImpl{client: mockClient}
@@ -0,0 +1,93 @@
+package shopify
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// DeprecatedReasonHeader is set by Shopify on responses to operations that
+// use deprecated fields.
+const DeprecatedReasonHeader = "X-Shopify-API-Deprecated-Reason"
+
+// Deprecation is a deprecated part of the Admin API an operation used.
+type Deprecation struct {
+	Operation string
+	Reason    string
+}
+
+type deprecationScopeKey struct{}
+
+type deprecationKey struct {
+	scope string
+	Deprecation
+}
+
+// WithDeprecationScope attributes the deprecations reported while running
+// operations with ctx to scope, usually a resource type name.
+func WithDeprecationScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, deprecationScopeKey{}, scope)
+}
+
+// TakeDeprecations returns the deprecations seen in scope that have not been
+// taken yet, so each one is only reported once.
+func (s *ShopifyAdminClinetImpl) TakeDeprecations(scope string) []Deprecation {
+	s.deprecationsMu.Lock()
+	defer s.deprecationsMu.Unlock()
+
+	var taken []Deprecation
+	for _, key := range s.deprecations {
+		if key.scope != scope || s.reportedDeprecations[key] {
+			continue
+		}
+
+		if s.reportedDeprecations == nil {
+			s.reportedDeprecations = map[deprecationKey]bool{}
+		}
+
+		s.reportedDeprecations[key] = true
+		taken = append(taken, key.Deprecation)
+	}
+
+	return taken
+}
+
+func (s *ShopifyAdminClinetImpl) recordDeprecations(ctx context.Context, operation string, reasons []string) {
+	scope, _ := ctx.Value(deprecationScopeKey{}).(string)
+
+	s.deprecationsMu.Lock()
+	defer s.deprecationsMu.Unlock()
+
+	for _, reason := range reasons {
+		key := deprecationKey{scope, Deprecation{Operation: operation, Reason: reason}}
+		if !slices.Contains(s.deprecations, key) {
+			s.deprecations = append(s.deprecations, key)
+		}
+	}
+}
+
+// operationName names query after its operation or, for anonymous
+// operations, its root fields.
+func operationName(query string) string {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil || len(doc.Operations) == 0 {
+		return "unknown"
+	}
+
+	op := doc.Operations[0]
+	if op.Name != "" {
+		return op.Name
+	}
+
+	var fields []string
+	for _, selection := range op.SelectionSet {
+		if field, ok := selection.(*ast.Field); ok {
+			fields = append(fields, field.Name)
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
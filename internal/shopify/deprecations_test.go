@@ -0,0 +1,62 @@
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecDeprecations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(DeprecatedReasonHeader, "https://shopify.dev/api/usage/versioning#deprecation-practices")
+		_, _ = w.Write([]byte(`{
+			"data": {"webhookSubscription": {"callbackUrl": "https://example.com"}},
+			"extensions": {
+				"deprecations": [
+					{"reason": "Use endpoint instead."},
+					{"message": "Use endpoint instead."},
+					"WebhookSubscription.callbackUrl is deprecated"
+				]
+			}
+		}`))
+	}))
+
+	defer server.Close()
+
+	client := New(server.URL[7:], "access_token", "2024-07", WithHTTPClient(server.Client()))
+	client.local = true
+
+	ctx := WithDeprecationScope(context.Background(), "shopify_pubsub_webhook")
+	query := `query { webhookSubscription(id: "gid://shopify/WebhookSubscription/1") { callbackUrl } }`
+
+	_, err := client.exec(ctx, query)
+	require.NoError(t, err)
+
+	_, err = client.exec(ctx, query)
+	require.NoError(t, err)
+
+	_, err = client.exec(context.Background(), query)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Deprecation{
+		{Operation: "webhookSubscription", Reason: "https://shopify.dev/api/usage/versioning#deprecation-practices"},
+		{Operation: "webhookSubscription", Reason: "Use endpoint instead."},
+		{Operation: "webhookSubscription", Reason: "WebhookSubscription.callbackUrl is deprecated"},
+	}, client.TakeDeprecations("shopify_pubsub_webhook"))
+
+	assert.Empty(t, client.TakeDeprecations("shopify_pubsub_webhook"))
+	assert.Len(t, client.TakeDeprecations(""), 3)
+	assert.Empty(t, client.TakeDeprecations("shopify_discount"))
+}
+
+func TestOperationName(t *testing.T) {
+	assert.Equal(t, "discountAutomaticDelete", operationName(`mutation discountAutomaticDelete { discountAutomaticDelete(id: "1") { deletedAutomaticDiscountId } }`))
+	assert.Equal(t, "shopifyFunctions", operationName(`query { shopifyFunctions(first: 250) { nodes { id } } }`))
+	assert.Equal(t, "a,b", operationName(`{ a b }`))
+	assert.Equal(t, "unknown", operationName(`not graphql`))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/tidwall/gjson"
 )
@@ -12,6 +13,7 @@ var _ discountService = (*discountServiceImpl)(nil)
 
 type discountService interface {
 	Get(ctx context.Context, discountID string) (*DiscountNode, error)
+	List(ctx context.Context, filter *DiscountFilter) ([]DiscountNode, error)
 	Create(ctx context.Context, functionID string, discount *DiscountNode) (*DiscountNode, error)
 	Update(ctx context.Context, discount *DiscountNode) (*DiscountNode, error)
 	Delete(ctx context.Context, discountID string) (*DiscountNode, error)
@@ -23,10 +25,23 @@ type discountServiceImpl struct {
 
 type DiscountNode struct {
 	ID           string
+	FunctionID   string
 	Title        string
 	StartsAt     string
 	EndsAt       string
 	CombinesWith *DiscountCombinesWith
+
+	// FunctionHandle, when set, is sent instead of the function ID on
+	// creation by API versions that accept it.
+	FunctionHandle string
+}
+
+// DiscountFilter narrows a listing of automatic app discounts. Zero fields
+// don't filter.
+type DiscountFilter struct {
+	// Status is ACTIVE, EXPIRED or SCHEDULED.
+	Status     string
+	FunctionID string
 }
 
 type DiscountCombinesWith struct {
@@ -45,6 +60,9 @@ func (d *discountServiceImpl) Get(
 				discount {
 				... on DiscountAutomaticApp {
 						discountId
+						appDiscountType {
+							functionId
+						}
 						title
 						startsAt
 						endsAt
@@ -66,21 +84,71 @@ func (d *discountServiceImpl) Get(
 	}
 
 	jsonb, _ := json.Marshal(r)
-	json := gjson.Parse(string(jsonb)).Get("discountNode.discount")
 
-	n := &DiscountNode{
-		ID:       json.Get("discountId").String(),
-		Title:    json.Get("title").String(),
-		StartsAt: json.Get("startsAt").String(),
-		EndsAt:   json.Get("endsAt").String(),
+	return automaticAppDiscountNode(gjson.Parse(string(jsonb)).Get("discountNode.discount")), nil
+}
+
+func automaticAppDiscountNode(json gjson.Result) *DiscountNode {
+	return &DiscountNode{
+		ID:         json.Get("discountId").String(),
+		FunctionID: json.Get("appDiscountType.functionId").String(),
+		Title:      json.Get("title").String(),
+		StartsAt:   json.Get("startsAt").String(),
+		EndsAt:     json.Get("endsAt").String(),
 		CombinesWith: &DiscountCombinesWith{
 			OrderDiscounts:    json.Get("combinesWith.orderDiscounts").Bool(),
 			ProductDiscounts:  json.Get("combinesWith.productDiscounts").Bool(),
 			ShippingDiscounts: json.Get("combinesWith.shippingDiscounts").Bool(),
 		},
 	}
+}
 
-	return n, nil
+// List returns the automatic app discounts that match filter, or all of them
+// when filter is nil. Automatic discounts that aren't backed by a function
+// are skipped.
+func (d *discountServiceImpl) List(
+	ctx context.Context,
+	filter *DiscountFilter,
+) ([]DiscountNode, error) {
+	gql := `
+		query {
+			automaticDiscountNodes(first: 250%s) {
+				nodes {
+					` + automaticAppDiscountFragment + `
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	search := ""
+	if filter != nil && filter.Status != "" {
+		search = "status:" + strings.ToLower(filter.Status)
+	}
+
+	nodes := []DiscountNode{}
+	err := listConnection(ctx, d.client, gql, "automaticDiscountNodes", searchArgs(search), func(node gjson.Result) {
+		n := automaticAppDiscountNode(node.Get("automaticDiscount"))
+		if n.ID == "" {
+			return
+		}
+
+		// The search syntax has no function filter, so it is applied here.
+		if filter != nil && filter.FunctionID != "" && filter.FunctionID != n.FunctionID {
+			return
+		}
+
+		nodes = append(nodes, *n)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
 }
 
 func (d *discountServiceImpl) Create(
@@ -92,7 +160,7 @@ func (d *discountServiceImpl) Create(
 		mutation {
 			discountAutomaticAppCreate(
 				automaticAppDiscount: {
-					functionId: "%s"
+					%s
 					title: "%s"
 					startsAt: "%s"
 					%s
@@ -105,6 +173,9 @@ func (d *discountServiceImpl) Create(
 			) {
 				automaticAppDiscount {
 					discountId
+					appDiscountType {
+						functionId
+					}
 					title
 					startsAt
 					endsAt
@@ -124,7 +195,7 @@ func (d *discountServiceImpl) Create(
 
 	gql = fmt.Sprintf(
 		gql,
-		functionID,
+		functionReference(d.client, functionID, discount.FunctionHandle),
 		discount.Title,
 		discount.StartsAt,
 		endsAtField,
@@ -144,10 +215,11 @@ func (d *discountServiceImpl) Create(
 		Get("discountAutomaticAppCreate.automaticAppDiscount")
 
 	n := &DiscountNode{
-		ID:       json.Get("discountId").String(),
-		Title:    json.Get("title").String(),
-		StartsAt: json.Get("startsAt").String(),
-		EndsAt:   json.Get("endsAt").String(),
+		ID:         json.Get("discountId").String(),
+		FunctionID: json.Get("appDiscountType.functionId").String(),
+		Title:      json.Get("title").String(),
+		StartsAt:   json.Get("startsAt").String(),
+		EndsAt:     json.Get("endsAt").String(),
 		CombinesWith: &DiscountCombinesWith{
 			OrderDiscounts:    json.Get("combinesWith.orderDiscounts").Bool(),
 			ProductDiscounts:  json.Get("combinesWith.productDiscounts").Bool(),
@@ -179,6 +251,9 @@ func (d *discountServiceImpl) Update(
 			) {
 				automaticAppDiscount {
 					discountId
+					appDiscountType {
+						functionId
+					}
 					title
 					startsAt
 					endsAt
@@ -219,10 +294,11 @@ func (d *discountServiceImpl) Update(
 		Get("discountAutomaticAppUpdate.automaticAppDiscount")
 
 	n := &DiscountNode{
-		ID:       json.Get("discountId").String(),
-		Title:    json.Get("title").String(),
-		StartsAt: json.Get("startsAt").String(),
-		EndsAt:   json.Get("endsAt").String(),
+		ID:         json.Get("discountId").String(),
+		FunctionID: json.Get("appDiscountType.functionId").String(),
+		Title:      json.Get("title").String(),
+		StartsAt:   json.Get("startsAt").String(),
+		EndsAt:     json.Get("endsAt").String(),
 		CombinesWith: &DiscountCombinesWith{
 			OrderDiscounts:    json.Get("combinesWith.orderDiscounts").Bool(),
 			ProductDiscounts:  json.Get("combinesWith.productDiscounts").Bool(),
@@ -3,6 +3,7 @@ package shopify
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,9 +22,12 @@ func TestDiscountService_Get(t *testing.T) {
 			"discountNode": map[string]interface{}{
 				"discount": map[string]interface{}{
 					"discountId": discountID,
-					"title":      "Test Discount",
-					"startsAt":   "2023-01-01T00:00:00Z",
-					"endsAt":     "2023-12-31T23:59:59Z",
+					"appDiscountType": map[string]interface{}{
+						"functionId": "07224386-3c16-4f9e-b8ba-da049b6afc66",
+					},
+					"title":    "Test Discount",
+					"startsAt": "2023-01-01T00:00:00Z",
+					"endsAt":   "2023-12-31T23:59:59Z",
 					"combinesWith": map[string]interface{}{
 						"orderDiscounts":    true,
 						"productDiscounts":  false,
@@ -40,6 +44,7 @@ func TestDiscountService_Get(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, discount)
 		assert.Equal(t, discountID, discount.ID)
+		assert.Equal(t, "07224386-3c16-4f9e-b8ba-da049b6afc66", discount.FunctionID)
 		assert.Equal(t, "Test Discount", discount.Title)
 		assert.Equal(t, "2023-01-01T00:00:00Z", discount.StartsAt)
 		assert.Equal(t, "2023-12-31T23:59:59Z", discount.EndsAt)
@@ -63,6 +68,93 @@ func TestDiscountService_Get(t *testing.T) {
 	})
 }
 
+func TestDiscountService_List(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &discountServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	appDiscount := func(id string, functionID string) map[string]interface{} {
+		return map[string]interface{}{
+			"automaticDiscount": map[string]interface{}{
+				"discountId": id,
+				"appDiscountType": map[string]interface{}{
+					"functionId": functionID,
+				},
+				"title":    "Test Discount",
+				"startsAt": "2023-01-01T00:00:00Z",
+				"endsAt":   nil,
+				"combinesWith": map[string]interface{}{
+					"orderDiscounts":    true,
+					"productDiscounts":  false,
+					"shippingDiscounts": false,
+				},
+			},
+		}
+	}
+
+	expectedResponse := map[string]interface{}{
+		"automaticDiscountNodes": map[string]interface{}{
+			"nodes": []interface{}{
+				appDiscount("gid://shopify/DiscountAutomaticNode/1", "07224386-3c16-4f9e-b8ba-da049b6afc66"),
+				// A basic automatic discount has no app discount fields.
+				map[string]interface{}{
+					"automaticDiscount": map[string]interface{}{},
+				},
+				appDiscount("gid://shopify/DiscountAutomaticNode/3", "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d"),
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	t.Run("Successful List", func(t *testing.T) {
+		mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+			return !strings.Contains(q, "query:")
+		})).Return(expectedResponse, nil).Once()
+
+		discounts, err := service.List(ctx, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, discounts, 2)
+		assert.Equal(t, "gid://shopify/DiscountAutomaticNode/1", discounts[0].ID)
+		assert.Equal(t, "", discounts[0].EndsAt)
+		assert.True(t, discounts[0].CombinesWith.OrderDiscounts)
+		assert.Equal(t, "gid://shopify/DiscountAutomaticNode/3", discounts[1].ID)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Filtered List", func(t *testing.T) {
+		mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+			return strings.Contains(q, `query: "status:active"`)
+		})).Return(expectedResponse, nil).Once()
+
+		discounts, err := service.List(ctx, &DiscountFilter{
+			Status:     "ACTIVE",
+			FunctionID: "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d",
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, discounts, 1)
+		assert.Equal(t, "gid://shopify/DiscountAutomaticNode/3", discounts[0].ID)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error in List", func(t *testing.T) {
+		mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(nil, errors.New("API error")).Once()
+
+		discounts, err := service.List(ctx, nil)
+
+		assert.Nil(t, discounts)
+		assert.EqualError(t, err, "API error")
+
+		mockClient.AssertExpectations(t)
+	})
+}
+
 func TestDiscountService_Create(t *testing.T) {
 	mockClient := new(mockShopifyAdminClient)
 	service := &discountServiceImpl{client: mockClient}
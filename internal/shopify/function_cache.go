@@ -0,0 +1,117 @@
+package shopify
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// FunctionCacheTTL bounds how long a cached function catalogue is used for.
+// Writes through the client drop the cache, but functions are deployed with
+// their app, outside the client.
+const FunctionCacheTTL = 5 * time.Minute
+
+// WithFunctionCache sets whether Function.List results are cached until the
+// client's next write, or for at most FunctionCacheTTL. The cache is on by
+// default.
+func WithFunctionCache(enabled bool) Option {
+	return func(c *ShopifyAdminClinetImpl) {
+		c.disableFunctionCache = !enabled
+	}
+}
+
+// cachedFunctionService lists the function catalogue once and shares the
+// result with every caller, including those that ask while the list is still
+// in flight, until it is invalidated or ttl passes.
+type cachedFunctionService struct {
+	FunctionService
+
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu         sync.Mutex
+	functions  *FunctionNodes
+	expires    time.Time
+	generation int
+}
+
+func (f *cachedFunctionService) List(ctx context.Context) (FunctionNodes, error) {
+	f.mu.Lock()
+	if f.functions != nil && time.Now().Before(f.expires) {
+		functions := *f.functions
+		f.mu.Unlock()
+
+		return functions, nil
+	}
+
+	generation := f.generation
+	f.mu.Unlock()
+
+	v, err, _ := f.group.Do("list", func() (any, error) {
+		// Callers share the list, so one giving up must not fail the others.
+		ctx, cancel := detach(ctx)
+		defer cancel()
+
+		functions, err := f.FunctionService.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		// A write while the list was in flight may have changed the
+		// catalogue, so only cache a list started after the last one.
+		if f.generation == generation {
+			f.functions = &functions
+			f.expires = time.Now().Add(f.ttl)
+		}
+
+		return functions, nil
+	})
+
+	if err != nil {
+		return FunctionNodes{}, err
+	}
+
+	return v.(FunctionNodes), nil
+}
+
+// invalidate drops the cached catalogue, so the next List fetches it again.
+func (f *cachedFunctionService) invalidate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.functions = nil
+	f.generation++
+	f.group.Forget("list")
+}
+
+// invalidateFunctionCache drops the cached function catalogue after query if
+// it is a mutation. The Admin API does not say which writes change the
+// catalogue, so every write is assumed to.
+func (s *ShopifyAdminClinetImpl) invalidateFunctionCache(query string) {
+	if s.functionCache == nil || !isMutation(query) {
+		return
+	}
+
+	s.functionCache.invalidate()
+}
+
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
+// detach returns a context with the values and deadline of ctx that is not
+// canceled along with it, for work shared with other callers.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+
+	return detached, func() {}
+}
@@ -0,0 +1,178 @@
+package shopify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFunctionCacheTestClient returns a client whose store lists one function,
+// and a counter of the times it was listed.
+func newFunctionCacheTestClient(t *testing.T, opts ...Option) (*ShopifyAdminClinetImpl, *atomic.Int32) {
+	t.Helper()
+
+	var lists atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.Contains(string(body), "shopifyFunctions") {
+			lists.Add(1)
+
+			// Give concurrent callers time to pile up.
+			time.Sleep(10 * time.Millisecond)
+
+			_, _ = w.Write([]byte(`{"data": {"shopifyFunctions": {"nodes": [{"id": "1", "title": "discount", "apiType": "product_discounts", "app": {"title": "app"}}]}}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data": {"test": "success"}}`))
+	}))
+
+	t.Cleanup(server.Close)
+
+	client := New(server.URL[7:], "access_token", "2024-07", append([]Option{WithHTTPClient(server.Client())}, opts...)...)
+	client.local = true
+
+	return client, &lists
+}
+
+func TestFunctionCache(t *testing.T) {
+	client, lists := newFunctionCacheTestClient(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			functions, err := client.Function.List(ctx)
+
+			assert.NoError(t, err)
+			assert.Len(t, functions.Nodes, 1)
+		}()
+	}
+
+	wg.Wait()
+
+	_, err := client.Function.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), lists.Load())
+
+	_, err = client.exec(ctx, `query { test }`)
+	require.NoError(t, err)
+
+	_, err = client.Function.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), lists.Load(), "queries do not invalidate the cache")
+
+	_, err = client.exec(ctx, `mutation { test }`)
+	require.NoError(t, err)
+
+	_, err = client.Function.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), lists.Load(), "mutations invalidate the cache")
+}
+
+func TestFunctionCache_WriteDuringList(t *testing.T) {
+	var calls int
+	var cache *cachedFunctionService
+	cache = &cachedFunctionService{
+		FunctionService: functionServiceFunc(func(ctx context.Context) (FunctionNodes, error) {
+			calls++
+			if calls == 1 {
+				cache.invalidate()
+			}
+
+			return FunctionNodes{}, nil
+		}),
+		ttl: time.Hour,
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.List(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, calls, "a list started before a write is not cached")
+}
+
+func TestFunctionCache_Expires(t *testing.T) {
+	var calls int
+	cache := &cachedFunctionService{
+		FunctionService: functionServiceFunc(func(ctx context.Context) (FunctionNodes, error) {
+			calls++
+			return FunctionNodes{}, nil
+		}),
+		ttl: 20 * time.Millisecond,
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.List(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, err := cache.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestFunctionCache_Disabled(t *testing.T) {
+	client, lists := newFunctionCacheTestClient(t, WithFunctionCache(false))
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Function.List(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(3), lists.Load())
+}
+
+func TestFunctionCache_Error(t *testing.T) {
+	var calls int
+	cache := &cachedFunctionService{
+		FunctionService: functionServiceFunc(func(ctx context.Context) (FunctionNodes, error) {
+			calls++
+			if calls == 1 {
+				return FunctionNodes{}, assert.AnError
+			}
+
+			return FunctionNodes{Nodes: []FunctionNode{{ID: "1"}}}, nil
+		}),
+		ttl: time.Hour,
+	}
+
+	_, err := cache.List(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+
+	functions, err := cache.List(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, functions.Nodes, 1)
+
+	_, err = cache.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+type functionServiceFunc func(ctx context.Context) (FunctionNodes, error)
+
+func (f functionServiceFunc) List(ctx context.Context) (FunctionNodes, error) {
+	return f(ctx)
+}
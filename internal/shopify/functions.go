@@ -2,7 +2,9 @@ package shopify
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/tidwall/gjson"
 )
@@ -19,6 +21,7 @@ type FunctionServiceImpl struct {
 
 type FunctionNode struct {
 	ID      string
+	Handle  string
 	Title   string
 	APIType string
 	APPName string
@@ -29,42 +32,85 @@ type FunctionNodes struct {
 }
 
 func (f *FunctionServiceImpl) List(ctx context.Context) (FunctionNodes, error) {
+	// ShopifyFunction.handle only exists on versions that can reference
+	// functions by handle.
+	handleField := ""
+	if SupportsFeature(f.client.ApiVersion(), FeatureFunctionHandle) {
+		handleField = "handle"
+	}
+
 	gql := `
 		query {
-			shopifyFunctions(first: 250) {
+			shopifyFunctions(first: 250%s) {
 				nodes {
 					id
+					` + handleField + `
 					title
 					apiType
 					app {
 						title
 					}
 				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
 			}
 		}
 	`
 
-	r, err := f.client.exec(ctx, gql)
 	var functionNodes FunctionNodes
+	err := listConnection(ctx, f.client, gql, "shopifyFunctions", "", func(value gjson.Result) {
+		functionNodes.Nodes = append(functionNodes.Nodes, FunctionNode{
+			ID:      value.Get("id").String(),
+			Handle:  value.Get("handle").String(),
+			Title:   value.Get("title").String(),
+			APIType: value.Get("apiType").String(),
+			APPName: value.Get("app.title").String(),
+		})
+	})
 	if err != nil {
-		return functionNodes, err
+		return FunctionNodes{}, err
 	}
 
-	jsonb, _ := json.Marshal(r)
-	gjson.
-		Parse(string(jsonb)).
-		Get("shopifyFunctions.nodes").
-		ForEach(func(_, value gjson.Result) bool {
-			functionNode := FunctionNode{
-				ID:      value.Get("id").String(),
-				Title:   value.Get("title").String(),
-				APIType: value.Get("apiType").String(),
-				APPName: value.Get("app.title").String(),
-			}
+	return functionNodes, nil
+}
 
-			functionNodes.Nodes = append(functionNodes.Nodes, functionNode)
-			return true
-		})
+// WithHandle returns the functions whose handle is handle. API versions
+// that don't list function handles match handle against the handle Shopify
+// CLI generates from a function's name, its title, instead.
+func (f FunctionNodes) WithHandle(apiVersion string, handle string) []FunctionNode {
+	var nodes []FunctionNode
+	for _, node := range f.Nodes {
+		nodeHandle := node.Handle
+		if !SupportsFeature(apiVersion, FeatureFunctionHandle) {
+			nodeHandle = handleFromName(node.Title)
+		}
 
-	return functionNodes, nil
+		if nodeHandle == handle {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+var nonHandleChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// handleFromName returns the handle Shopify CLI generates for an extension
+// named name.
+func handleFromName(name string) string {
+	return strings.Trim(nonHandleChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// functionReference returns the input field that points a new discount or
+// customization at its function: functionHandle when functionHandle is set and
+// the API version accepts it, functionId otherwise. On earlier versions the
+// handle was resolved to functionID when the resource was planned.
+func functionReference(client shopifyAdminClient, functionID string, functionHandle string) string {
+	if functionHandle != "" && SupportsFeature(client.ApiVersion(), FeatureFunctionHandle) {
+		return fmt.Sprintf(`functionHandle: "%s"`, functionHandle)
+	}
+
+	return fmt.Sprintf(`functionId: "%s"`, functionID)
 }
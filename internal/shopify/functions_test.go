@@ -2,6 +2,7 @@ package shopify
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,6 +61,54 @@ func TestFunctionService_List(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestFunctionService_ListPages(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &FunctionServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	firstPage := map[string]interface{}{
+		"shopifyFunctions": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{"id": "function-1", "title": "Function 1"},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": true,
+				"endCursor":   "cursor-1",
+			},
+		},
+	}
+
+	secondPage := map[string]interface{}{
+		"shopifyFunctions": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{"id": "function-2", "title": "Function 2"},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return !strings.Contains(q, "after:")
+	})).Return(firstPage, nil).Once()
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, `after: "cursor-1"`)
+	})).Return(secondPage, nil).Once()
+
+	functionNodes, err := service.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []FunctionNode{
+		{ID: "function-1", Title: "Function 1"},
+		{ID: "function-2", Title: "Function 2"},
+	}, functionNodes.Nodes)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestFunctionService_ListEmpty(t *testing.T) {
 	mockClient := new(mockShopifyAdminClient)
 	service := &FunctionServiceImpl{client: mockClient}
@@ -83,6 +132,66 @@ func TestFunctionService_ListEmpty(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestFunctionService_ListHandle(t *testing.T) {
+	mockClient := &mockShopifyAdminClient{apiVersion: "2025-04"}
+	service := &FunctionServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	expectedResponse := map[string]interface{}{
+		"shopifyFunctions": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":      "function-1",
+					"handle":  "product-discount",
+					"title":   "Product Discount",
+					"apiType": "product_discounts",
+					"app": map[string]interface{}{
+						"title": "tf-testing",
+					},
+				},
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "handle")
+	})).Return(expectedResponse, nil)
+
+	functionNodes, err := service.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, functionNodes.Nodes, 1)
+	assert.Equal(t, "product-discount", functionNodes.Nodes[0].Handle)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestFunctionNodes_WithHandle(t *testing.T) {
+	functions := FunctionNodes{Nodes: []FunctionNode{
+		{ID: "1", Handle: "volume-discount", Title: "Volume discount"},
+		{ID: "2", Handle: "tiered-discount", Title: "Volume Discount!"},
+		{ID: "3", Title: "Hide payment methods"},
+	}}
+
+	ids := func(nodes []FunctionNode) []string {
+		var ids []string
+		for _, node := range nodes {
+			ids = append(ids, node.ID)
+		}
+
+		return ids
+	}
+
+	assert.Equal(t, []string{"1"}, ids(functions.WithHandle("2025-04", "volume-discount")))
+	assert.Empty(t, functions.WithHandle("2025-04", "hide-payment-methods"))
+
+	// Earlier versions don't list handles, so titles are matched instead.
+	assert.Equal(t, []string{"1", "2"}, ids(functions.WithHandle("2025-01", "volume-discount")))
+	assert.Equal(t, []string{"3"}, ids(functions.WithHandle("2025-01", "hide-payment-methods")))
+	assert.Empty(t, functions.WithHandle("2025-01", "tiered-discount"))
+}
+
 func TestFunctionService_ListError(t *testing.T) {
 	mockClient := new(mockShopifyAdminClient)
 	service := &FunctionServiceImpl{client: mockClient}
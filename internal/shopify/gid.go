@@ -0,0 +1,94 @@
+package shopify
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const gidPrefix = "gid://shopify/"
+
+var (
+	gidTypePattern     = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	gidLegacyIDPattern = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// ErrInvalidGIDType and ErrInvalidGIDID tell which part NewGID rejected.
+var (
+	ErrInvalidGIDType = errors.New("invalid GID type")
+	ErrInvalidGIDID   = errors.New("invalid GID id")
+)
+
+// GID is a Shopify global ID, e.g. gid://shopify/DiscountAutomaticNode/123.
+type GID struct {
+	Type   string
+	ID     string
+	Params map[string]string
+}
+
+func NewGID(typ string, id string) (GID, error) {
+	if !gidTypePattern.MatchString(typ) {
+		return GID{}, fmt.Errorf("%w %q, must be a PascalCase object name such as DiscountAutomaticNode", ErrInvalidGIDType, typ)
+	}
+
+	if id == "" || strings.ContainsAny(id, "/?") {
+		return GID{}, fmt.Errorf("%w %q, must be non-empty and not contain '/' or '?'", ErrInvalidGIDID, id)
+	}
+
+	return GID{Type: typ, ID: id, Params: map[string]string{}}, nil
+}
+
+func ParseGID(s string) (GID, error) {
+	rest, ok := strings.CutPrefix(s, gidPrefix)
+	if !ok {
+		return GID{}, fmt.Errorf("invalid GID %q, must start with %q", s, gidPrefix)
+	}
+
+	rest, query, _ := strings.Cut(rest, "?")
+
+	typ, id, ok := strings.Cut(rest, "/")
+	if !ok {
+		return GID{}, fmt.Errorf("invalid GID %q, must be formatted as %s<type>/<id>", s, gidPrefix)
+	}
+
+	gid, err := NewGID(typ, id)
+	if err != nil {
+		return GID{}, err
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return GID{}, fmt.Errorf("invalid GID %q, malformed parameters: %w", s, err)
+	}
+
+	for key := range values {
+		gid.Params[key] = values.Get(key)
+	}
+
+	return gid, nil
+}
+
+// LegacyID returns the numeric REST resource ID the GID wraps.
+func (g GID) LegacyID() (string, error) {
+	if !gidLegacyIDPattern.MatchString(g.ID) {
+		return "", fmt.Errorf("GID %q has no numeric legacy resource ID", g.String())
+	}
+
+	return g.ID, nil
+}
+
+func (g GID) String() string {
+	s := gidPrefix + g.Type + "/" + g.ID
+	if len(g.Params) == 0 {
+		return s
+	}
+
+	values := url.Values{}
+	for key, value := range g.Params {
+		values.Set(key, value)
+	}
+
+	return s + "?" + values.Encode()
+}
@@ -0,0 +1,80 @@
+package shopify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGID(t *testing.T) {
+	t.Run("Successful parse", func(t *testing.T) {
+		gid, err := ParseGID("gid://shopify/DiscountAutomaticNode/123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "DiscountAutomaticNode", gid.Type)
+		assert.Equal(t, "123", gid.ID)
+		assert.Empty(t, gid.Params)
+	})
+
+	t.Run("Parse with parameters", func(t *testing.T) {
+		gid, err := ParseGID("gid://shopify/ImageSource/123?width=100&height=50")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ImageSource", gid.Type)
+		assert.Equal(t, "123", gid.ID)
+		assert.Equal(t, map[string]string{"width": "100", "height": "50"}, gid.Params)
+	})
+
+	t.Run("Malformed input", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"123",
+			"gid://shopify/",
+			"gid://shopify/DiscountAutomaticNode",
+			"gid://shopify/DiscountAutomaticNode/",
+			"gid://shopify//123",
+			"gid://shopify/discountAutomaticNode/123",
+			"gid://shopify/DiscountAutomaticNode/1/2",
+			"gid://other/DiscountAutomaticNode/123",
+			"gid://shopify/ImageSource/123?width=%zz",
+		} {
+			_, err := ParseGID(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestNewGID(t *testing.T) {
+	gid, err := NewGID("PaymentCustomization", "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "gid://shopify/PaymentCustomization/1", gid.String())
+
+	_, err = NewGID("payment customization", "1")
+	assert.Error(t, err)
+
+	_, err = NewGID("PaymentCustomization", "")
+	assert.Error(t, err)
+
+	_, err = NewGID("PaymentCustomization", "1?x=y")
+	assert.Error(t, err)
+}
+
+func TestGID_LegacyID(t *testing.T) {
+	gid, _ := ParseGID("gid://shopify/WebhookSubscription/42")
+	id, err := gid.LegacyID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+
+	gid, _ = ParseGID("gid://shopify/ShopifyFunction/07224386-3c16-4f9e-b8ba-da049b6afc66")
+	_, err = gid.LegacyID()
+
+	assert.Error(t, err)
+}
+
+func TestGID_String(t *testing.T) {
+	gid, _ := ParseGID("gid://shopify/ImageSource/123?width=100")
+
+	assert.Equal(t, "gid://shopify/ImageSource/123?width=100", gid.String())
+}
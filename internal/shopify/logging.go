@@ -0,0 +1,129 @@
+package shopify
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// LogSubsystem is the tflog subsystem every Admin API operation is logged
+// to. Set TF_LOG_PROVIDER_SHOPIFY_CLIENT to change its level.
+const LogSubsystem = "shopify_client"
+
+// RequestIDHeader identifies a request when contacting Shopify support.
+const RequestIDHeader = "X-Request-Id"
+
+const maskedValue = "***"
+
+// secretFieldKeys are log fields whose values are always masked.
+var secretFieldKeys = []string{"access_token", "client_secret", "subject_token"}
+
+// sensitiveArguments are substrings of argument names whose values are
+// masked when an operation's variables are logged. Callback URLs often carry
+// a shared secret in their query string.
+var sensitiveArguments = []string{"token", "secret", "password", "callbackurl"}
+
+// logContext returns ctx with the shopify_client subsystem, masking the
+// credentials it may log and every occurrence of token.
+func logContext(ctx context.Context, token string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem)
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, secretFieldKeys...)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, secretFieldKeys...)
+
+	if token != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, token)
+	}
+
+	return ctx
+}
+
+// logOperation logs the outcome of running query.
+func logOperation(ctx context.Context, query string, token string, response *operationResponse, latency time.Duration, err error) {
+	fields := map[string]any{
+		"operation":    operationName(query),
+		"variables":    operationVariables(query),
+		"access_token": token,
+		"status_code":  response.statusCode,
+		"request_id":   response.requestID,
+		"latency_ms":   latency.Milliseconds(),
+	}
+
+	if response.cost.Exists() {
+		fields["requested_query_cost"] = response.cost.Get("requestedQueryCost").Int()
+		fields["actual_query_cost"] = response.cost.Get("actualQueryCost").Int()
+		fields["throttle_currently_available"] = response.cost.Get("throttleStatus.currentlyAvailable").Int()
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemError(ctx, LogSubsystem, "Shopify Admin API operation failed", fields)
+
+		return
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Shopify Admin API operation", fields)
+}
+
+// operationVariables returns the arguments query passes to its root fields.
+// Operations are sent with their values inline, so these are what other
+// clients would send as variables. Sensitive values are masked.
+func operationVariables(query string) map[string]any {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil || len(doc.Operations) == 0 {
+		return nil
+	}
+
+	variables := map[string]any{}
+	for _, selection := range doc.Operations[0].SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok {
+			continue
+		}
+
+		for _, argument := range field.Arguments {
+			variables[argument.Name] = argumentValue(argument.Name, argument.Value)
+		}
+	}
+
+	return variables
+}
+
+func argumentValue(name string, value *ast.Value) any {
+	if isSensitiveArgument(name) {
+		return maskedValue
+	}
+
+	switch value.Kind {
+	case ast.ObjectValue:
+		object := map[string]any{}
+		for _, child := range value.Children {
+			object[child.Name] = argumentValue(child.Name, child.Value)
+		}
+
+		return object
+	case ast.ListValue:
+		list := []any{}
+		for _, child := range value.Children {
+			list = append(list, argumentValue(name, child.Value))
+		}
+
+		return list
+	default:
+		return value.Raw
+	}
+}
+
+func isSensitiveArgument(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveArguments {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
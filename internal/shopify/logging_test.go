@@ -0,0 +1,107 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(RequestIDHeader, "request-1")
+		_, _ = w.Write([]byte(`{
+			"data": {"webhookSubscriptionDelete": {"deletedWebhookSubscriptionId": "1"}},
+			"extensions": {"cost": {"requestedQueryCost": 10, "actualQueryCost": 8, "throttleStatus": {"currentlyAvailable": 1992}}}
+		}`))
+	}))
+
+	defer server.Close()
+
+	client := New(server.URL[7:], "shpat_secret", "2024-07", WithHTTPClient(server.Client()))
+	client.local = true
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err := client.exec(ctx, `
+		mutation webhookSubscriptionDelete {
+			webhookSubscriptionDelete(id: "gid://shopify/WebhookSubscription/1", input: {callbackUrl: "https://example.com?token=shpat_secret", format: JSON}) {
+				deletedWebhookSubscriptionId
+			}
+		}
+	`)
+	require.NoError(t, err)
+
+	assert.NotContains(t, output.String(), "shpat_secret")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, map[string]any{
+		"@level":                       "debug",
+		"@message":                     "Shopify Admin API operation",
+		"@module":                      "provider." + LogSubsystem,
+		"operation":                    "webhookSubscriptionDelete",
+		"access_token":                 maskedValue,
+		"status_code":                  float64(200),
+		"request_id":                   "request-1",
+		"latency_ms":                   entries[0]["latency_ms"],
+		"requested_query_cost":         float64(10),
+		"actual_query_cost":            float64(8),
+		"throttle_currently_available": float64(1992),
+		"variables": map[string]any{
+			"id": "gid://shopify/WebhookSubscription/1",
+			"input": map[string]any{
+				"callbackUrl": maskedValue,
+				"format":      "JSON",
+			},
+		},
+	}, entries[0])
+}
+
+func TestExecLogging_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"errors": [{"message": "Field 'missing' doesn't exist on type 'QueryRoot'"}]}`))
+	}))
+
+	defer server.Close()
+
+	client := New(server.URL[7:], "shpat_secret", "2024-07", WithHTTPClient(server.Client()))
+	client.local = true
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err := client.exec(ctx, `query { missing }`)
+	require.Error(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, "error", entries[0]["@level"])
+	assert.Equal(t, "missing", entries[0]["operation"])
+	assert.Contains(t, entries[0]["error"], "Field 'missing' doesn't exist")
+}
+
+func TestOperationVariables(t *testing.T) {
+	assert.Equal(t, map[string]any{
+		"first": "250",
+		"ids":   []any{"1", "2"},
+	}, operationVariables(`query { nodes(ids: ["1", "2"]) { id } shopifyFunctions(first: 250) { nodes { id } } }`))
+
+	assert.Equal(t, map[string]any{
+		"input": map[string]any{"sessionToken": maskedValue, "title": "t"},
+	}, operationVariables(`mutation { a(input: {sessionToken: "x", title: "t"}) { id } }`))
+
+	assert.Nil(t, operationVariables(`not graphql`))
+}
@@ -0,0 +1,384 @@
+package shopify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedMetafieldType is returned by EncodeMetafieldValue for a type
+// it can't encode, as opposed to a value that doesn't match the type.
+var ErrUnsupportedMetafieldType = errors.New("unsupported metafield type")
+
+type metafieldEncoder struct {
+	// encode converts a plain Go value into the JSON element Shopify stores
+	// for the type. String elements are used verbatim as the metafield value.
+	encode   func(value any) (any, error)
+	listable bool
+}
+
+var (
+	metafieldColorPattern    = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	metafieldCurrencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	metafieldIntegerPattern  = regexp.MustCompile(`^-?[0-9]+$`)
+)
+
+var metafieldEncoders = map[string]metafieldEncoder{
+	"single_line_text_field": {encode: encodeMetafieldSingleLine, listable: true},
+	"multi_line_text_field":  {encode: encodeMetafieldString},
+	"url":                    {encode: encodeMetafieldURL, listable: true},
+	"color":                  {encode: encodeMetafieldColor, listable: true},
+	"date":                   {encode: encodeMetafieldDate, listable: true},
+	"date_time":              {encode: encodeMetafieldDateTime, listable: true},
+	"boolean":                {encode: encodeMetafieldBoolean},
+	"number_integer":         {encode: encodeMetafieldInteger, listable: true},
+	"number_decimal":         {encode: encodeMetafieldDecimal, listable: true},
+	"json":                   {encode: encodeMetafieldJSON},
+	"money":                  {encode: encodeMetafieldMoney},
+	"rating":                 {encode: encodeMetafieldRating, listable: true},
+	"weight": {
+		encode:   encodeMetafieldMeasurement("GRAMS", "KILOGRAMS", "OUNCES", "POUNDS"),
+		listable: true,
+	},
+	"volume": {
+		encode: encodeMetafieldMeasurement(
+			"MILLILITERS", "CENTILITERS", "LITERS", "CUBIC_METERS",
+			"FLUID_OUNCES", "PINTS", "QUARTS", "GALLONS",
+			"IMPERIAL_FLUID_OUNCES", "IMPERIAL_PINTS", "IMPERIAL_QUARTS", "IMPERIAL_GALLONS",
+		),
+		listable: true,
+	},
+	"dimension": {
+		encode:   encodeMetafieldMeasurement("MILLIMETERS", "CENTIMETERS", "METERS", "INCHES", "FEET", "YARDS"),
+		listable: true,
+	},
+	"collection_reference": {encode: encodeMetafieldReference("Collection"), listable: true},
+	"company_reference":    {encode: encodeMetafieldReference("Company"), listable: true},
+	"customer_reference":   {encode: encodeMetafieldReference("Customer"), listable: true},
+	"file_reference":       {encode: encodeMetafieldReference(), listable: true},
+	"metaobject_reference": {encode: encodeMetafieldReference("Metaobject"), listable: true},
+	"mixed_reference":      {encode: encodeMetafieldReference("Metaobject"), listable: true},
+	"page_reference":       {encode: encodeMetafieldReference("Page"), listable: true},
+	"product_reference":    {encode: encodeMetafieldReference("Product"), listable: true},
+	"variant_reference":    {encode: encodeMetafieldReference("ProductVariant"), listable: true},
+}
+
+// MetafieldTypes returns the metafield types EncodeMetafieldValue supports,
+// including their list.* variants.
+func MetafieldTypes() []string {
+	types := []string{}
+	for name, encoder := range metafieldEncoders {
+		types = append(types, name)
+		if encoder.listable {
+			types = append(types, "list."+name)
+		}
+	}
+
+	sort.Strings(types)
+	return types
+}
+
+// EncodeMetafieldValue serializes value the way the Admin API expects for a
+// metafield of type typ. value holds plain Go values: string, bool,
+// json.Number, map[string]any and []any.
+func EncodeMetafieldValue(typ string, value any) (string, error) {
+	name, isList := strings.CutPrefix(typ, "list.")
+
+	encoder, ok := metafieldEncoders[name]
+	if !ok || (isList && !encoder.listable) {
+		return "", fmt.Errorf("%w %q", ErrUnsupportedMetafieldType, typ)
+	}
+
+	if value == nil {
+		return "", fmt.Errorf("metafield value must not be null")
+	}
+
+	if !isList {
+		element, err := encoder.encode(value)
+		if err != nil {
+			return "", err
+		}
+
+		if s, ok := element.(string); ok && name != "json" {
+			return s, nil
+		}
+
+		return marshalMetafieldElement(element)
+	}
+
+	values, ok := value.([]any)
+	if !ok {
+		return "", fmt.Errorf("%s value must be a list, got %s", typ, metafieldValueKind(value))
+	}
+
+	elements := make([]any, 0, len(values))
+	for i, v := range values {
+		element, err := encoder.encode(v)
+		if err != nil {
+			return "", fmt.Errorf("element %d: %w", i, err)
+		}
+
+		elements = append(elements, element)
+	}
+
+	return marshalMetafieldElement(elements)
+}
+
+func marshalMetafieldElement(element any) (string, error) {
+	b, err := json.Marshal(element)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func metafieldValueKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case json.Number:
+		return "number"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "list"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func encodeMetafieldString(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("value must be a string, got %s", metafieldValueKind(value))
+	}
+
+	return s, nil
+}
+
+func encodeMetafieldSingleLine(value any) (any, error) {
+	s, err := encodeMetafieldString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ContainsAny(s.(string), "\r\n") {
+		return nil, fmt.Errorf("single_line_text_field value must not contain line breaks")
+	}
+
+	return s, nil
+}
+
+func encodeMetafieldURL(value any) (any, error) {
+	s, err := encodeMetafieldString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(s.(string))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("url value %q must be an absolute http or https URL", s)
+	}
+
+	return s, nil
+}
+
+func encodeMetafieldColor(value any) (any, error) {
+	s, err := encodeMetafieldString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metafieldColorPattern.MatchString(s.(string)) {
+		return nil, fmt.Errorf("color value %q must be a hexadecimal color such as #FF0000", s)
+	}
+
+	return s, nil
+}
+
+func encodeMetafieldDate(value any) (any, error) {
+	s, err := encodeMetafieldString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse(time.DateOnly, s.(string)); err != nil {
+		return nil, fmt.Errorf("date value %q must be formatted as YYYY-MM-DD", s)
+	}
+
+	return s, nil
+}
+
+func encodeMetafieldDateTime(value any) (any, error) {
+	s, err := encodeMetafieldString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse(time.RFC3339, s.(string)); err != nil {
+		return nil, fmt.Errorf("date_time value %q must be an RFC 3339 timestamp", s)
+	}
+
+	return s, nil
+}
+
+func encodeMetafieldBoolean(value any) (any, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("value must be a bool, got %s", metafieldValueKind(value))
+	}
+
+	return b, nil
+}
+
+func encodeMetafieldInteger(value any) (any, error) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("value must be a number, got %s", metafieldValueKind(value))
+	}
+
+	if !metafieldIntegerPattern.MatchString(n.String()) {
+		return nil, fmt.Errorf("number_integer value %s must be a whole number", n)
+	}
+
+	return n, nil
+}
+
+// encodeMetafieldDecimal also accepts strings, since decimals are stored as
+// strings and Terraform numbers can't always represent them exactly.
+func encodeMetafieldDecimal(value any) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), nil
+	case string:
+		if _, ok := new(big.Float).SetString(v); !ok {
+			return nil, fmt.Errorf("number_decimal value %q must be a decimal number", v)
+		}
+
+		return v, nil
+	default:
+		return nil, fmt.Errorf("value must be a number, got %s", metafieldValueKind(value))
+	}
+}
+
+func encodeMetafieldJSON(value any) (any, error) {
+	return value, nil
+}
+
+func encodeMetafieldMoney(value any) (any, error) {
+	m, err := metafieldObject(value, "amount", "currency_code")
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := encodeMetafieldDecimal(m["amount"])
+	if err != nil {
+		return nil, fmt.Errorf("amount: %w", err)
+	}
+
+	currency, ok := m["currency_code"].(string)
+	if !ok || !metafieldCurrencyPattern.MatchString(currency) {
+		return nil, fmt.Errorf("currency_code must be an ISO 4217 currency code such as USD")
+	}
+
+	return map[string]any{"amount": amount, "currency_code": currency}, nil
+}
+
+func encodeMetafieldRating(value any) (any, error) {
+	m, err := metafieldObject(value, "value", "scale_min", "scale_max")
+	if err != nil {
+		return nil, err
+	}
+
+	rating := map[string]any{}
+	for _, key := range []string{"value", "scale_min", "scale_max"} {
+		v, err := encodeMetafieldDecimal(m[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		rating[key] = v
+	}
+
+	return rating, nil
+}
+
+func encodeMetafieldMeasurement(units ...string) func(value any) (any, error) {
+	return func(value any) (any, error) {
+		m, err := metafieldObject(value, "value", "unit")
+		if err != nil {
+			return nil, err
+		}
+
+		n, ok := m["value"].(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("value must be a number, got %s", metafieldValueKind(m["value"]))
+		}
+
+		unit, _ := m["unit"].(string)
+		if slices.Contains(units, unit) {
+			return map[string]any{"value": n, "unit": unit}, nil
+		}
+
+		return nil, fmt.Errorf("unit must be one of %s", strings.Join(units, ", "))
+	}
+}
+
+// encodeMetafieldReference accepts GIDs of the given object types, or any
+// GID when no types are given.
+func encodeMetafieldReference(types ...string) func(value any) (any, error) {
+	return func(value any) (any, error) {
+		s, err := encodeMetafieldString(value)
+		if err != nil {
+			return nil, err
+		}
+
+		gid, err := ParseGID(s.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(types) == 0 {
+			return s, nil
+		}
+
+		if slices.Contains(types, gid.Type) {
+			return s, nil
+		}
+
+		return nil, fmt.Errorf("reference %q must point to a %s", s, strings.Join(types, " or "))
+	}
+}
+
+func metafieldObject(value any, keys ...string) (map[string]any, error) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("value must be an object with %s, got %s", strings.Join(keys, " and "), metafieldValueKind(value))
+	}
+
+	for _, key := range keys {
+		if _, ok := m[key]; !ok {
+			return nil, fmt.Errorf("value is missing the %s attribute", key)
+		}
+	}
+
+	for key := range m {
+		if !slices.Contains(keys, key) {
+			return nil, fmt.Errorf("value has unexpected attribute %s", key)
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,108 @@
+package shopify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeMetafieldValue(t *testing.T) {
+	t.Run("Successful encode", func(t *testing.T) {
+		cases := []struct {
+			typ      string
+			value    any
+			expected string
+		}{
+			{"single_line_text_field", "hello", "hello"},
+			{"multi_line_text_field", "hello\nworld", "hello\nworld"},
+			{"url", "https://example.com/path", "https://example.com/path"},
+			{"color", "#FF00aa", "#FF00aa"},
+			{"date", "2024-07-01", "2024-07-01"},
+			{"date_time", "2024-07-01T10:00:00Z", "2024-07-01T10:00:00Z"},
+			{"boolean", true, "true"},
+			{"number_integer", json.Number("42"), "42"},
+			{"number_decimal", json.Number("4.2"), "4.2"},
+			{"number_decimal", "10.40", "10.40"},
+			{"json", "text", `"text"`},
+			{
+				"json",
+				map[string]any{"threshold": json.Number("100"), "tags": []any{"vip"}},
+				`{"tags":["vip"],"threshold":100}`,
+			},
+			{
+				"money",
+				map[string]any{"amount": json.Number("5.99"), "currency_code": "USD"},
+				`{"amount":"5.99","currency_code":"USD"}`,
+			},
+			{
+				"rating",
+				map[string]any{"value": json.Number("3.5"), "scale_min": json.Number("1"), "scale_max": json.Number("5")},
+				`{"scale_max":"5","scale_min":"1","value":"3.5"}`,
+			},
+			{
+				"weight",
+				map[string]any{"value": json.Number("2.5"), "unit": "KILOGRAMS"},
+				`{"unit":"KILOGRAMS","value":2.5}`,
+			},
+			{"product_reference", "gid://shopify/Product/1", "gid://shopify/Product/1"},
+			{"file_reference", "gid://shopify/MediaImage/1", "gid://shopify/MediaImage/1"},
+			{"list.single_line_text_field", []any{"a", "b"}, `["a","b"]`},
+			{"list.number_integer", []any{json.Number("1"), json.Number("2")}, `[1,2]`},
+			{"list.number_decimal", []any{json.Number("1.5")}, `["1.5"]`},
+			{"list.variant_reference", []any{}, `[]`},
+		}
+
+		for _, c := range cases {
+			value, err := EncodeMetafieldValue(c.typ, c.value)
+
+			assert.NoError(t, err, c.typ)
+			assert.Equal(t, c.expected, value, c.typ)
+		}
+	})
+
+	t.Run("Mismatched value", func(t *testing.T) {
+		cases := []struct {
+			typ   string
+			value any
+		}{
+			{"unknown_type", "x"},
+			{"list.boolean", []any{true}},
+			{"list.json", []any{"x"}},
+			{"single_line_text_field", nil},
+			{"single_line_text_field", json.Number("1")},
+			{"single_line_text_field", "line\nbreak"},
+			{"url", "example.com"},
+			{"color", "red"},
+			{"date", "07/01/2024"},
+			{"date_time", "2024-07-01"},
+			{"boolean", "true"},
+			{"number_integer", json.Number("1.5")},
+			{"number_decimal", "abc"},
+			{"money", map[string]any{"amount": json.Number("5.99")}},
+			{"money", map[string]any{"amount": json.Number("5.99"), "currency_code": "usd"}},
+			{"money", map[string]any{"amount": json.Number("5.99"), "currency_code": "USD", "extra": "x"}},
+			{"money", json.Number("5.99")},
+			{"weight", map[string]any{"value": json.Number("1"), "unit": "STONES"}},
+			{"product_reference", "gid://shopify/Collection/1"},
+			{"product_reference", "1"},
+			{"list.single_line_text_field", "a"},
+			{"list.number_integer", []any{json.Number("1"), "2"}},
+		}
+
+		for _, c := range cases {
+			_, err := EncodeMetafieldValue(c.typ, c.value)
+
+			assert.Error(t, err, c.typ)
+		}
+	})
+}
+
+func TestMetafieldTypes(t *testing.T) {
+	types := MetafieldTypes()
+
+	assert.Contains(t, types, "json")
+	assert.Contains(t, types, "list.single_line_text_field")
+	assert.NotContains(t, types, "list.json")
+	assert.IsIncreasing(t, types)
+}
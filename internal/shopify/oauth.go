@@ -0,0 +1,165 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	idTokenType            = "urn:ietf:params:oauth:token-type:id_token"
+	offlineAccessTokenType = "urn:shopify:params:oauth:token-type:offline-access-token"
+	clientCredentialsGrant = "client_credentials"
+
+	// tokenExpiryMargin refreshes tokens a little before Shopify expires
+	// them, so an operation never starts with a token about to lapse.
+	tokenExpiryMargin = time.Minute
+)
+
+// OAuth holds the app credentials used to obtain access tokens instead of a
+// static store access token.
+type OAuth struct {
+	ClientID     string
+	ClientSecret string
+	// SessionToken, when set, is exchanged for an offline access token.
+	// Without it the client credentials grant is used.
+	SessionToken string
+}
+
+type accessToken struct {
+	value     string
+	expiresAt time.Time
+}
+
+// WithOAuth fetches access tokens with the app credentials in oauth, caches
+// them until they expire and fetches a new one when Shopify rejects the
+// cached token.
+func WithOAuth(oauth OAuth) Option {
+	return func(c *ShopifyAdminClinetImpl) {
+		c.oauth = &oauth
+	}
+}
+
+// Authenticate fetches an access token, if the client uses OAuth and has
+// none cached.
+func (s *ShopifyAdminClinetImpl) Authenticate(ctx context.Context) error {
+	_, err := s.accessToken(ctx)
+	return err
+}
+
+func (s *ShopifyAdminClinetImpl) accessToken(ctx context.Context) (string, error) {
+	if s.oauth == nil {
+		return s.storeAccessToken, nil
+	}
+
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
+	if s.token != nil && (s.token.expiresAt.IsZero() || time.Now().Before(s.token.expiresAt)) {
+		return s.token.value, nil
+	}
+
+	token, err := s.fetchAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	return token.value, nil
+}
+
+// invalidateAccessToken drops the cached token if it is still token, so the
+// next operation fetches a new one.
+func (s *ShopifyAdminClinetImpl) invalidateAccessToken(token string) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
+	if s.token != nil && s.token.value == token {
+		s.token = nil
+	}
+}
+
+func (s *ShopifyAdminClinetImpl) fetchAccessToken(ctx context.Context) (*accessToken, error) {
+	body := map[string]string{
+		"client_id":     s.oauth.ClientID,
+		"client_secret": s.oauth.ClientSecret,
+		"grant_type":    clientCredentialsGrant,
+	}
+
+	if s.oauth.SessionToken != "" {
+		body["grant_type"] = tokenExchangeGrantType
+		body["subject_token"] = s.oauth.SessionToken
+		body["subject_token_type"] = idTokenType
+		body["requested_token_type"] = offlineAccessTokenType
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s://%s/admin/oauth/access_token", s.scheme(), s.storeDomain)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	ctx = logContext(ctx, s.oauth.ClientSecret)
+	start := time.Now()
+
+	res, err := s.sharedHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Shopify access token request", map[string]any{
+		"grant_type":  body["grant_type"],
+		"client_id":   s.oauth.ClientID,
+		"status_code": res.StatusCode,
+		"request_id":  res.Header.Get(RequestIDHeader),
+		"latency_ms":  time.Since(start).Milliseconds(),
+	})
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := gjson.ParseBytes(data)
+	if res.StatusCode != http.StatusOK {
+		reason := result.Get("error_description").String()
+		if reason == "" {
+			reason = result.Get("error").String()
+		}
+
+		if reason == "" {
+			reason = http.StatusText(res.StatusCode)
+		}
+
+		return nil, fmt.Errorf("failed to fetch access token (%s grant): %d %s", body["grant_type"], res.StatusCode, reason)
+	}
+
+	token := &accessToken{value: result.Get("access_token").String()}
+	if token.value == "" {
+		return nil, fmt.Errorf("failed to fetch access token (%s grant): response has no access_token", body["grant_type"])
+	}
+
+	if expiresIn := result.Get("expires_in").Int(); expiresIn > 0 {
+		token.expiresAt = time.Now().Add(time.Duration(expiresIn)*time.Second - tokenExpiryMargin)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,142 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOAuthTestClient(t *testing.T, handler http.HandlerFunc, oauth OAuth) *ShopifyAdminClinetImpl {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New(server.URL[7:], "", "2024-07", WithHTTPClient(server.Client()), WithOAuth(oauth))
+	client.local = true
+
+	return client
+}
+
+func TestOAuth(t *testing.T) {
+	t.Run("Client credentials grant", func(t *testing.T) {
+		var tokenRequests atomic.Int32
+
+		client := newOAuthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.URL.Path == "/admin/oauth/access_token" {
+				tokenRequests.Add(1)
+
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, map[string]string{
+					"client_id":     "client-id",
+					"client_secret": "client-secret",
+					"grant_type":    "client_credentials",
+				}, body)
+
+				_, _ = w.Write([]byte(`{"access_token": "shpat_fetched", "scope": "write_discounts", "expires_in": 86399}`))
+				return
+			}
+
+			assert.Equal(t, "shpat_fetched", r.Header.Get("X-Shopify-Access-Token"))
+			_, _ = w.Write([]byte(`{"data": {"test": "success"}}`))
+		}, OAuth{ClientID: "client-id", ClientSecret: "client-secret"})
+
+		require.NoError(t, client.Authenticate(context.Background()))
+
+		for i := 0; i < 2; i++ {
+			_, err := client.exec(context.Background(), `query { test }`)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), tokenRequests.Load())
+		assert.WithinDuration(t, time.Now().Add(86399*time.Second-tokenExpiryMargin), client.token.expiresAt, time.Minute)
+	})
+
+	t.Run("Token exchange grant", func(t *testing.T) {
+		client := newOAuthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{
+				"client_id":            "client-id",
+				"client_secret":        "client-secret",
+				"grant_type":           "urn:ietf:params:oauth:grant-type:token-exchange",
+				"subject_token":        "session-token",
+				"subject_token_type":   "urn:ietf:params:oauth:token-type:id_token",
+				"requested_token_type": "urn:shopify:params:oauth:token-type:offline-access-token",
+			}, body)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token": "shpat_exchanged", "scope": "write_discounts"}`))
+		}, OAuth{ClientID: "client-id", ClientSecret: "client-secret", SessionToken: "session-token"})
+
+		require.NoError(t, client.Authenticate(context.Background()))
+		assert.Equal(t, "shpat_exchanged", client.token.value)
+		assert.True(t, client.token.expiresAt.IsZero())
+	})
+
+	t.Run("Refresh on 401", func(t *testing.T) {
+		var tokenRequests atomic.Int32
+
+		client := newOAuthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.URL.Path == "/admin/oauth/access_token" {
+				if tokenRequests.Add(1) == 1 {
+					_, _ = w.Write([]byte(`{"access_token": "shpat_revoked"}`))
+				} else {
+					_, _ = w.Write([]byte(`{"access_token": "shpat_fresh"}`))
+				}
+
+				return
+			}
+
+			if r.Header.Get("X-Shopify-Access-Token") != "shpat_fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"errors": "[API] Invalid API key or access token"}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"data": {"test": "success"}}`))
+		}, OAuth{ClientID: "client-id", ClientSecret: "client-secret"})
+
+		res, err := client.exec(context.Background(), `query { test }`)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"test": "success"}, res)
+		assert.Equal(t, int32(2), tokenRequests.Load())
+	})
+
+	t.Run("Rejected credentials", func(t *testing.T) {
+		client := newOAuthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_client", "error_description": "Client credentials are invalid"}`))
+		}, OAuth{ClientID: "client-id", ClientSecret: "wrong"})
+
+		err := client.Authenticate(context.Background())
+
+		assert.EqualError(t, err, "failed to fetch access token (client_credentials grant): 400 Client credentials are invalid")
+
+		_, err = client.exec(context.Background(), `query { test }`)
+		assert.Error(t, err)
+	})
+
+	t.Run("Static access token", func(t *testing.T) {
+		client := New("example.myshopify.com", "shpat_static", "2024-07")
+
+		token, err := client.accessToken(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "shpat_static", token)
+	})
+}
@@ -12,6 +12,7 @@ var _ paymentService = (*paymentServiceImpl)(nil)
 
 type paymentService interface {
 	Get(ctx context.Context, paymentID string) (*PaymentNode, error)
+	List(ctx context.Context, filter *CustomizationFilter) ([]PaymentNode, error)
 	Create(ctx context.Context, functionID string, payment *PaymentNode) (*PaymentNode, error)
 	Update(ctx context.Context, payment *PaymentNode) (*PaymentNode, error)
 	Delete(ctx context.Context, paymentID string) (*PaymentNode, error)
@@ -22,9 +23,14 @@ type paymentServiceImpl struct {
 }
 
 type PaymentNode struct {
-	ID      string
-	Title   string
-	Enabled bool
+	ID         string
+	FunctionID string
+	Title      string
+	Enabled    bool
+
+	// FunctionHandle, when set, is sent instead of the function ID on
+	// creation by API versions that accept it.
+	FunctionHandle string
 }
 
 func (p *paymentServiceImpl) Get(ctx context.Context, paymentID string) (*PaymentNode, error) {
@@ -32,6 +38,7 @@ func (p *paymentServiceImpl) Get(ctx context.Context, paymentID string) (*Paymen
 		query {
 			paymentCustomization(id: "%s") {
 				id
+				functionId
 				title
 				enabled
 			}
@@ -45,15 +52,46 @@ func (p *paymentServiceImpl) Get(ctx context.Context, paymentID string) (*Paymen
 	}
 
 	jsonb, _ := json.Marshal(r)
-	json := gjson.Parse(string(jsonb)).Get("paymentCustomization")
 
-	n := &PaymentNode{
-		ID:      json.Get("id").String(),
-		Title:   json.Get("title").String(),
-		Enabled: json.Get("enabled").Bool(),
+	return paymentCustomizationNode(gjson.Parse(string(jsonb)).Get("paymentCustomization")), nil
+}
+
+func paymentCustomizationNode(json gjson.Result) *PaymentNode {
+	return &PaymentNode{
+		ID:         json.Get("id").String(),
+		FunctionID: json.Get("functionId").String(),
+		Title:      json.Get("title").String(),
+		Enabled:    json.Get("enabled").Bool(),
 	}
+}
 
-	return n, nil
+// List returns the payment customizations that match filter, or all of
+// them when filter is nil.
+func (p *paymentServiceImpl) List(ctx context.Context, filter *CustomizationFilter) ([]PaymentNode, error) {
+	gql := `
+		query {
+			paymentCustomizations(first: 250%s) {
+				nodes {
+					` + paymentCustomizationFragment + `
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	nodes := []PaymentNode{}
+	err := listConnection(ctx, p.client, gql, "paymentCustomizations", searchArgs(filter.search()), func(node gjson.Result) {
+		nodes = append(nodes, *paymentCustomizationNode(node))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
 }
 
 func (p *paymentServiceImpl) Create(ctx context.Context, functionID string, payment *PaymentNode) (*PaymentNode, error) {
@@ -61,13 +99,14 @@ func (p *paymentServiceImpl) Create(ctx context.Context, functionID string, paym
 		mutation {
 			paymentCustomizationCreate(
 				paymentCustomization: {
-					functionId: "%s"
+					%s
 					title: "%s"
 					enabled: %t
 				}
 			) {
 				paymentCustomization {
 					id
+					functionId
 					title
 					enabled
 				}
@@ -75,7 +114,13 @@ func (p *paymentServiceImpl) Create(ctx context.Context, functionID string, paym
 		}
 	`
 
-	gql = fmt.Sprintf(gql, functionID, payment.Title, payment.Enabled)
+	gql = fmt.Sprintf(
+		gql,
+		functionReference(p.client, functionID, payment.FunctionHandle),
+		payment.Title,
+		payment.Enabled,
+	)
+
 	r, err := p.client.exec(ctx, gql)
 	if err != nil {
 		return nil, err
@@ -85,9 +130,10 @@ func (p *paymentServiceImpl) Create(ctx context.Context, functionID string, paym
 	json := gjson.Parse(string(jsonb)).Get("paymentCustomizationCreate.paymentCustomization")
 
 	n := &PaymentNode{
-		ID:      json.Get("id").String(),
-		Title:   json.Get("title").String(),
-		Enabled: json.Get("enabled").Bool(),
+		ID:         json.Get("id").String(),
+		FunctionID: json.Get("functionId").String(),
+		Title:      json.Get("title").String(),
+		Enabled:    json.Get("enabled").Bool(),
 	}
 
 	return n, nil
@@ -105,6 +151,7 @@ func (p *paymentServiceImpl) Update(ctx context.Context, payment *PaymentNode) (
 			) {
 				paymentCustomization {
 					id
+					functionId
 					title
 					enabled
 				}
@@ -122,9 +169,10 @@ func (p *paymentServiceImpl) Update(ctx context.Context, payment *PaymentNode) (
 	json := gjson.Parse(string(jsonb)).Get("paymentCustomizationUpdate.paymentCustomization")
 
 	n := &PaymentNode{
-		ID:      json.Get("id").String(),
-		Title:   json.Get("title").String(),
-		Enabled: json.Get("enabled").Bool(),
+		ID:         json.Get("id").String(),
+		FunctionID: json.Get("functionId").String(),
+		Title:      json.Get("title").String(),
+		Enabled:    json.Get("enabled").Bool(),
 	}
 
 	return n, nil
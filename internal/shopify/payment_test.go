@@ -2,6 +2,7 @@ package shopify
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,9 +18,10 @@ func TestPaymentService_Get(t *testing.T) {
 
 	expectedResponse := map[string]interface{}{
 		"paymentCustomization": map[string]interface{}{
-			"id":      paymentID,
-			"title":   "Test Payment",
-			"enabled": true,
+			"id":         paymentID,
+			"functionId": "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+			"title":      "Test Payment",
+			"enabled":    true,
 		},
 	}
 
@@ -30,12 +32,111 @@ func TestPaymentService_Get(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, payment)
 	assert.Equal(t, paymentID, payment.ID)
+	assert.Equal(t, "f2e906be-a93a-48c6-a2cc-99c64e5ab816", payment.FunctionID)
 	assert.Equal(t, "Test Payment", payment.Title)
 	assert.True(t, payment.Enabled)
 
 	mockClient.AssertExpectations(t)
 }
 
+func TestPaymentService_List(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &paymentServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	firstPage := map[string]interface{}{
+		"paymentCustomizations": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":         "gid://shopify/PaymentCustomization/1",
+					"functionId": "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+					"title":      "Hide COD",
+					"enabled":    true,
+				},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": true,
+				"endCursor":   "cursor-1",
+			},
+		},
+	}
+
+	secondPage := map[string]interface{}{
+		"paymentCustomizations": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":         "gid://shopify/PaymentCustomization/2",
+					"functionId": "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+					"title":      "Reorder methods",
+					"enabled":    false,
+				},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return !strings.Contains(q, "after:")
+	})).Return(firstPage, nil).Once()
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, `after: "cursor-1"`)
+	})).Return(secondPage, nil).Once()
+
+	payments, err := service.List(ctx, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []PaymentNode{
+		{
+			ID:         "gid://shopify/PaymentCustomization/1",
+			FunctionID: "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+			Title:      "Hide COD",
+			Enabled:    true,
+		},
+		{
+			ID:         "gid://shopify/PaymentCustomization/2",
+			FunctionID: "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+			Title:      "Reorder methods",
+		},
+	}, payments)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestPaymentService_ListFilter(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &paymentServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	expectedResponse := map[string]interface{}{
+		"paymentCustomizations": map[string]interface{}{
+			"nodes": []interface{}{},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, `query: "function_id:f2e906be-a93a-48c6-a2cc-99c64e5ab816 enabled:false"`)
+	})).Return(expectedResponse, nil).Once()
+
+	enabled := false
+	payments, err := service.List(ctx, &CustomizationFilter{
+		FunctionID: "f2e906be-a93a-48c6-a2cc-99c64e5ab816",
+		Enabled:    &enabled,
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, payments)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestPaymentService_Create(t *testing.T) {
 	mockClient := new(mockShopifyAdminClient)
 	service := &paymentServiceImpl{client: mockClient}
@@ -71,6 +172,30 @@ func TestPaymentService_Create(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestPaymentService_CreateFunctionHandle(t *testing.T) {
+	ctx := context.Background()
+
+	for apiVersion, reference := range map[string]string{
+		"2025-01": `functionId: "gid://shopify/ShopifyFunction/1"`,
+		"2025-04": `functionHandle: "payment-customization"`,
+	} {
+		mockClient := &mockShopifyAdminClient{apiVersion: apiVersion}
+		service := &paymentServiceImpl{client: mockClient}
+
+		mockClient.On("exec", ctx, mock.MatchedBy(func(query string) bool {
+			return strings.Contains(query, reference)
+		})).Return(map[string]interface{}{}, nil)
+
+		_, err := service.Create(ctx, "gid://shopify/ShopifyFunction/1", &PaymentNode{
+			Title:          "New Payment",
+			FunctionHandle: "payment-customization",
+		})
+
+		assert.NoError(t, err, apiVersion)
+		mockClient.AssertExpectations(t)
+	}
+}
+
 func TestPaymentService_Update(t *testing.T) {
 	mockClient := new(mockShopifyAdminClient)
 	service := &paymentServiceImpl{client: mockClient}
@@ -147,6 +272,22 @@ func TestPaymentService_GetError(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestPaymentService_ListError(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &paymentServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(nil, assert.AnError)
+
+	payments, err := service.List(ctx, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, payments)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestPaymentService_CreateError(t *testing.T) {
 	mockClient := new(mockShopifyAdminClient)
 	service := &paymentServiceImpl{client: mockClient}
@@ -44,15 +44,7 @@ func (p *pubsubWebhookServiceImpl) Create(
 				}
 		) {
 				webhookSubscription {
-					id
-					topic
-					format
-					endpoint {
-						... on WebhookPubSubEndpoint {
-							pubSubProject
-							pubSubTopic
-						}
-					}
+					` + webhookSubscriptionFragment + `
 				}
 			}
 		}
@@ -74,15 +66,7 @@ func (p *pubsubWebhookServiceImpl) Create(
 	jsonb, _ := json.Marshal(r)
 	json := gjson.Parse(string(jsonb)).Get("pubSubWebhookSubscriptionCreate.webhookSubscription")
 
-	hook := &PubsubWebhook{
-		ID:            json.Get("id").String(),
-		Topic:         json.Get("topic").String(),
-		Format:        json.Get("format").String(),
-		PubSubProject: json.Get("endpoint.pubSubProject").String(),
-		PubSubTopic:   json.Get("endpoint.pubSubTopic").String(),
-	}
-
-	return hook, nil
+	return pubsubWebhook(json), nil
 }
 
 func (p *pubsubWebhookServiceImpl) Get(
@@ -92,21 +76,7 @@ func (p *pubsubWebhookServiceImpl) Get(
 	gql := `
 		query {
 			webhookSubscription(id: "%s") {
-				id
-				topic
-				format
-				endpoint {
-					... on WebhookHttpEndpoint {
-						callbackUrl
-					}
-					... on WebhookEventBridgeEndpoint {
-						arn
-					}
-					... on WebhookPubSubEndpoint {
-						pubSubProject
-						pubSubTopic
-					}
-				}
+				` + webhookSubscriptionFragment + `
 			}
 		}
 	`
@@ -120,15 +90,7 @@ func (p *pubsubWebhookServiceImpl) Get(
 	jsonb, _ := json.Marshal(r)
 	json := gjson.Parse(string(jsonb)).Get("webhookSubscription")
 
-	hook := &PubsubWebhook{
-		ID:            json.Get("id").String(),
-		Topic:         json.Get("topic").String(),
-		Format:        json.Get("format").String(),
-		PubSubProject: json.Get("endpoint.pubSubProject").String(),
-		PubSubTopic:   json.Get("endpoint.pubSubTopic").String(),
-	}
-
-	return hook, nil
+	return pubsubWebhook(json), nil
 }
 
 func (p *pubsubWebhookServiceImpl) Update(
@@ -146,15 +108,7 @@ func (p *pubsubWebhookServiceImpl) Update(
 		    }
 		) {
 		    webhookSubscription {
-		      id
-		      topic
-		      format
-		      endpoint {
-		        ... on WebhookPubSubEndpoint {
-		          pubSubProject
-		          pubSubTopic
-		        }
-		      }
+		      ` + webhookSubscriptionFragment + `
 		    }
 		  }
 		}
@@ -176,15 +130,7 @@ func (p *pubsubWebhookServiceImpl) Update(
 	jsonb, _ := json.Marshal(r)
 	json := gjson.Parse(string(jsonb)).Get("pubSubWebhookSubscriptionUpdate.webhookSubscription")
 
-	hook := &PubsubWebhook{
-		ID:            json.Get("id").String(),
-		Topic:         json.Get("topic").String(),
-		Format:        json.Get("format").String(),
-		PubSubProject: json.Get("endpoint.pubSubProject").String(),
-		PubSubTopic:   json.Get("endpoint.pubSubTopic").String(),
-	}
-
-	return hook, nil
+	return pubsubWebhook(json), nil
 }
 
 func (p *pubsubWebhookServiceImpl) Delete(
@@ -203,3 +149,17 @@ func (p *pubsubWebhookServiceImpl) Delete(
 	_, err := p.client.exec(ctx, gql)
 	return err
 }
+
+// pubsubWebhook reads a webhook subscription selected with
+// webhookSubscriptionFragment.
+func pubsubWebhook(json gjson.Result) *PubsubWebhook {
+	subscription := webhookSubscription(json)
+
+	return &PubsubWebhook{
+		ID:            subscription.ID,
+		Topic:         subscription.Topic,
+		Format:        subscription.Format,
+		PubSubProject: subscription.PubSubProject,
+		PubSubTopic:   subscription.PubSubTopic,
+	}
+}
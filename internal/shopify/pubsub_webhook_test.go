@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tidwall/gjson"
 )
 
 func TestPubsubWebhookService_Create(t *testing.T) {
@@ -228,3 +229,27 @@ func TestPubsubWebhookService_DeleteError(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestPubsubWebhook_ReadsWebhookSubscription(t *testing.T) {
+	json := gjson.Parse(`{
+		"id": "gid://shopify/WebhookSubscription/1",
+		"topic": "ORDERS_CREATE",
+		"format": "JSON",
+		"endpoint": {
+			"__typename": "WebhookPubSubEndpoint",
+			"pubSubProject": "test-project",
+			"pubSubTopic": "test-topic"
+		}
+	}`)
+
+	subscription := webhookSubscription(json)
+
+	assert.Equal(t, WebhookEndpointPubSub, subscription.EndpointType)
+	assert.Equal(t, &PubsubWebhook{
+		ID:            subscription.ID,
+		Topic:         subscription.Topic,
+		Format:        subscription.Format,
+		PubSubProject: subscription.PubSubProject,
+		PubSubTopic:   subscription.PubSubTopic,
+	}, pubsubWebhook(json))
+}
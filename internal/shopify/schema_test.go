@@ -0,0 +1,342 @@
+package shopify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type recordingClient struct {
+	apiVersion string
+	queries    []string
+}
+
+func (c *recordingClient) exec(_ context.Context, query string) (any, error) {
+	c.queries = append(c.queries, query)
+	return nil, nil
+}
+
+func (c *recordingClient) ApiVersion() string {
+	return c.apiVersion
+}
+
+type schemaOperation struct {
+	name  string
+	query string
+}
+
+// schemaOperationCalls calls every service method, with optional inputs set,
+// so the queries they build can be captured.
+var schemaOperationCalls = map[string]func(ctx context.Context, c shopifyAdminClient){
+	"Discount.Get": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&discountServiceImpl{c}).Get(ctx, "gid://shopify/DiscountAutomaticNode/1")
+	},
+	"Discount.List": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&discountServiceImpl{c}).List(ctx, &DiscountFilter{Status: "ACTIVE", FunctionID: "function-id"})
+	},
+	"Discount.Create": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&discountServiceImpl{c}).Create(ctx, "function-id", &DiscountNode{
+			Title:          "title",
+			StartsAt:       "2024-01-01T00:00:00Z",
+			EndsAt:         "2024-02-01T00:00:00Z",
+			CombinesWith:   &DiscountCombinesWith{OrderDiscounts: true},
+			FunctionHandle: "handle",
+		})
+	},
+	"Discount.Update": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&discountServiceImpl{c}).Update(ctx, &DiscountNode{
+			ID:           "gid://shopify/DiscountAutomaticNode/1",
+			Title:        "title",
+			StartsAt:     "2024-01-01T00:00:00Z",
+			EndsAt:       "2024-02-01T00:00:00Z",
+			CombinesWith: &DiscountCombinesWith{ProductDiscounts: true},
+		})
+	},
+	"Discount.Delete": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&discountServiceImpl{c}).Delete(ctx, "gid://shopify/DiscountAutomaticNode/1")
+	},
+	"Payment.Get": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&paymentServiceImpl{c}).Get(ctx, "gid://shopify/PaymentCustomization/1")
+	},
+	"Payment.List": func(ctx context.Context, c shopifyAdminClient) {
+		enabled := true
+		_, _ = (&paymentServiceImpl{c}).List(ctx, &CustomizationFilter{FunctionID: "function-id", Enabled: &enabled})
+	},
+	"Payment.Create": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&paymentServiceImpl{c}).Create(ctx, "function-id", &PaymentNode{Title: "title", Enabled: true, FunctionHandle: "handle"})
+	},
+	"Payment.Update": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&paymentServiceImpl{c}).Update(ctx, &PaymentNode{ID: "gid://shopify/PaymentCustomization/1", Title: "title"})
+	},
+	"Payment.Delete": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&paymentServiceImpl{c}).Delete(ctx, "gid://shopify/PaymentCustomization/1")
+	},
+	"Delivery.Get": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&deliveryServiceImpl{c}).Get(ctx, "gid://shopify/DeliveryCustomization/1")
+	},
+	"Delivery.List": func(ctx context.Context, c shopifyAdminClient) {
+		enabled := true
+		_, _ = (&deliveryServiceImpl{c}).List(ctx, &CustomizationFilter{FunctionID: "function-id", Enabled: &enabled})
+	},
+	"Delivery.Create": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&deliveryServiceImpl{c}).Create(ctx, "function-id", &DeliveryNode{Title: "title", Enabled: true, FunctionHandle: "handle"})
+	},
+	"Delivery.Update": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&deliveryServiceImpl{c}).Update(ctx, &DeliveryNode{ID: "gid://shopify/DeliveryCustomization/1", Title: "title"})
+	},
+	"Delivery.Delete": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&deliveryServiceImpl{c}).Delete(ctx, "gid://shopify/DeliveryCustomization/1")
+	},
+	"Function.List": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&FunctionServiceImpl{c}).List(ctx)
+	},
+	"Validation.List": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&validationServiceImpl{c}).List(ctx)
+	},
+	"CartTransform.List": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&cartTransformServiceImpl{c}).List(ctx)
+	},
+	"PubsubWebhook.Create": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&pubsubWebhookServiceImpl{c}).Create(ctx, &PubsubWebhook{
+			Topic:         "ORDERS_CREATE",
+			Format:        "JSON",
+			PubSubProject: "project",
+			PubSubTopic:   "topic",
+		})
+	},
+	"PubsubWebhook.Get": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&pubsubWebhookServiceImpl{c}).Get(ctx, "gid://shopify/WebhookSubscription/1")
+	},
+	"PubsubWebhook.Update": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&pubsubWebhookServiceImpl{c}).Update(ctx, &PubsubWebhook{
+			ID:            "gid://shopify/WebhookSubscription/1",
+			Format:        "JSON",
+			PubSubProject: "project",
+			PubSubTopic:   "topic",
+		})
+	},
+	"PubsubWebhook.Delete": func(ctx context.Context, c shopifyAdminClient) {
+		_ = (&pubsubWebhookServiceImpl{c}).Delete(ctx, "gid://shopify/WebhookSubscription/1")
+	},
+	"AppInstallation.Get": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&appInstallationServiceImpl{c}).Get(ctx)
+	},
+	"nodeLoader.load": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = c.exec(ctx, nodesQuery(
+			[]string{"gid://shopify/DiscountAutomaticNode/1", "gid://shopify/PaymentCustomization/1"},
+			map[string]bool{
+				automaticAppDiscountFragment:  true,
+				paymentCustomizationFragment:  true,
+				deliveryCustomizationFragment: true,
+			},
+		))
+	},
+	"Shop.Get": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&shopServiceImpl{c}).Get(ctx)
+	},
+	"WebhookSubscription.List": func(ctx context.Context, c shopifyAdminClient) {
+		_, _ = (&webhookSubscriptionServiceImpl{c}).List(ctx, &WebhookSubscriptionFilter{
+			Topics:      []string{"ORDERS_CREATE", "ORDERS_UPDATED"},
+			CallbackURL: "https://example.com/webhooks",
+			Format:      "JSON",
+		})
+	},
+}
+
+// captureOperations returns the operations every service method sends to
+// apiVersion.
+func captureOperations(t *testing.T, apiVersion string) []schemaOperation {
+	t.Helper()
+
+	var ops []schemaOperation
+	for name, call := range schemaOperationCalls {
+		c := &recordingClient{apiVersion: apiVersion}
+		call(context.Background(), c)
+
+		require.NotEmpty(t, c.queries, name)
+		for _, query := range c.queries {
+			ops = append(ops, schemaOperation{name: name, query: query})
+		}
+	}
+
+	return ops
+}
+
+func loadSchemas(t *testing.T) map[string]*ast.Schema {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "schema", "*.graphql"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	schemas := map[string]*ast.Schema{}
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: path, Input: string(input)})
+		require.Nil(t, gqlErr, path)
+
+		schemas[strings.TrimSuffix(filepath.Base(path), ".graphql")] = schema
+	}
+
+	return schemas
+}
+
+// deprecatedUsages returns every deprecated field, argument and enum value
+// that doc, validated against its schema, refers to.
+func deprecatedUsages(doc *ast.QueryDocument) []string {
+	var usages []string
+
+	var walkValue func(value *ast.Value)
+	walkValue = func(value *ast.Value) {
+		if value == nil {
+			return
+		}
+
+		if value.Kind == ast.EnumValue && value.Definition != nil {
+			if enum := value.Definition.EnumValues.ForName(value.Raw); enum != nil && enum.Directives.ForName("deprecated") != nil {
+				usages = append(usages, value.Definition.Name+"."+value.Raw)
+			}
+		}
+
+		for _, child := range value.Children {
+			walkValue(child.Value)
+		}
+	}
+
+	var walk func(selections ast.SelectionSet)
+	walk = func(selections ast.SelectionSet) {
+		for _, selection := range selections {
+			switch s := selection.(type) {
+			case *ast.Field:
+				if s.Definition != nil && s.Definition.Directives.ForName("deprecated") != nil {
+					usages = append(usages, s.ObjectDefinition.Name+"."+s.Name)
+				}
+
+				for _, arg := range s.Arguments {
+					if s.Definition != nil {
+						if def := s.Definition.Arguments.ForName(arg.Name); def != nil && def.Directives.ForName("deprecated") != nil {
+							usages = append(usages, s.ObjectDefinition.Name+"."+s.Name+"("+arg.Name+")")
+						}
+					}
+
+					walkValue(arg.Value)
+				}
+
+				walk(s.SelectionSet)
+			case *ast.InlineFragment:
+				walk(s.SelectionSet)
+			case *ast.FragmentSpread:
+				if s.Definition != nil {
+					walk(s.Definition.SelectionSet)
+				}
+			}
+		}
+	}
+
+	for _, op := range doc.Operations {
+		walk(op.SelectionSet)
+	}
+
+	return usages
+}
+
+func TestOperationsMatchSchema(t *testing.T) {
+	schemas := loadSchemas(t)
+
+	for version, schema := range schemas {
+		for _, op := range captureOperations(t, version) {
+			doc, errs := gqlparser.LoadQuery(schema, op.query)
+			if !assert.Empty(t, errs, "%s against %s", op.name, version) {
+				continue
+			}
+
+			for _, usage := range deprecatedUsages(doc) {
+				t.Errorf("%s uses %s, which is deprecated in %s", op.name, usage, version)
+			}
+		}
+	}
+}
+
+func TestSchemaOperationCallsCoverServices(t *testing.T) {
+	client := reflect.TypeOf(ShopifyAdminClinetImpl{})
+
+	for i := 0; i < client.NumField(); i++ {
+		field := client.Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Interface {
+			continue
+		}
+
+		for j := 0; j < field.Type.NumMethod(); j++ {
+			name := field.Name + "." + field.Type.Method(j).Name
+			assert.Contains(t, schemaOperationCalls, name, "add %s to schemaOperationCalls", name)
+		}
+	}
+}
+
+func TestSchemaVersions(t *testing.T) {
+	schemas := loadSchemas(t)
+
+	for _, version := range ApiVersions() {
+		assert.Contains(t, schemas, version, "missing testdata/schema/%s.graphql", version)
+	}
+
+	for _, version := range WebhookTopicVersions() {
+		assert.Contains(t, schemas, version, "missing testdata/schema/%s.graphql", version)
+	}
+}
+
+func TestSchemaWebhookTopics(t *testing.T) {
+	for version, schema := range loadSchemas(t) {
+		enum := schema.Types["WebhookSubscriptionTopic"]
+		require.NotNil(t, enum, version)
+
+		expected := map[string]bool{}
+		for _, topic := range WebhookTopics(version) {
+			expected[topic.Name] = topic.Deprecated
+		}
+
+		actual := map[string]bool{}
+		for _, value := range enum.EnumValues {
+			actual[value.Name] = value.Directives.ForName("deprecated") != nil
+		}
+
+		assert.Equal(t, expected, actual, version)
+	}
+}
+
+func TestDeprecatedUsages(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		type Query {
+			webhook(format: Format, legacy: Boolean @deprecated): Webhook
+		}
+
+		type Webhook {
+			id: ID!
+			callbackUrl: String @deprecated(reason: "Use endpoint")
+		}
+
+		enum Format {
+			JSON
+			XML @deprecated
+		}
+	`})
+
+	doc, errs := gqlparser.LoadQuery(schema, `query { webhook(format: XML, legacy: true) { id callbackUrl } }`)
+	require.Empty(t, errs)
+
+	assert.ElementsMatch(t, []string{
+		"Webhook.callbackUrl",
+		"Query.webhook(legacy)",
+		"Format.XML",
+	}, deprecatedUsages(doc))
+}
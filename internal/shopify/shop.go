@@ -0,0 +1,76 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tidwall/gjson"
+)
+
+var _ shopService = (*shopServiceImpl)(nil)
+
+type shopService interface {
+	Get(ctx context.Context) (*Shop, error)
+}
+
+type shopServiceImpl struct {
+	client shopifyAdminClient
+}
+
+type Shop struct {
+	ID                           string
+	Name                         string
+	MyshopifyDomain              string
+	PrimaryDomain                string
+	CurrencyCode                 string
+	IanaTimezone                 string
+	Plan                         string
+	EnabledPresentmentCurrencies []string
+}
+
+func (s *shopServiceImpl) Get(ctx context.Context) (*Shop, error) {
+	gql := `
+		query {
+			shop {
+				id
+				name
+				myshopifyDomain
+				primaryDomain {
+					host
+				}
+				currencyCode
+				ianaTimezone
+				plan {
+					displayName
+				}
+				enabledPresentmentCurrencies
+			}
+		}
+	`
+
+	r, err := s.client.exec(ctx, gql)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonb, _ := json.Marshal(r)
+	json := gjson.Parse(string(jsonb)).Get("shop")
+
+	n := &Shop{
+		ID:                           json.Get("id").String(),
+		Name:                         json.Get("name").String(),
+		MyshopifyDomain:              json.Get("myshopifyDomain").String(),
+		PrimaryDomain:                json.Get("primaryDomain.host").String(),
+		CurrencyCode:                 json.Get("currencyCode").String(),
+		IanaTimezone:                 json.Get("ianaTimezone").String(),
+		Plan:                         json.Get("plan.displayName").String(),
+		EnabledPresentmentCurrencies: []string{},
+	}
+
+	json.Get("enabledPresentmentCurrencies").ForEach(func(_, value gjson.Result) bool {
+		n.EnabledPresentmentCurrencies = append(n.EnabledPresentmentCurrencies, value.String())
+		return true
+	})
+
+	return n, nil
+}
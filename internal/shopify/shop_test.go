@@ -0,0 +1,51 @@
+package shopify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShopService_Get(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &shopServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	expectedResponse := map[string]interface{}{
+		"shop": map[string]interface{}{
+			"id":              "gid://shopify/Shop/1",
+			"name":            "tf-testing",
+			"myshopifyDomain": "tf-testing.myshopify.com",
+			"primaryDomain": map[string]interface{}{
+				"host": "shop.example.com",
+			},
+			"currencyCode": "CAD",
+			"ianaTimezone": "America/Toronto",
+			"plan": map[string]interface{}{
+				"displayName": "Basic",
+			},
+			"enabledPresentmentCurrencies": []interface{}{"CAD", "USD"},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(expectedResponse, nil)
+
+	shop, err := service.Get(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Shop{
+		ID:                           "gid://shopify/Shop/1",
+		Name:                         "tf-testing",
+		MyshopifyDomain:              "tf-testing.myshopify.com",
+		PrimaryDomain:                "shop.example.com",
+		CurrencyCode:                 "CAD",
+		IanaTimezone:                 "America/Toronto",
+		Plan:                         "Basic",
+		EnabledPresentmentCurrencies: []string{"CAD", "USD"},
+	}, shop)
+
+	mockClient.AssertExpectations(t)
+}
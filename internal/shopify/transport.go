@@ -0,0 +1,143 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// DefaultMaxIdleConns is the number of idle connections to the store kept
+// open between operations. It matches Terraform's default parallelism, so a
+// graph walk does not have to open new connections once warmed up.
+const DefaultMaxIdleConns = 10
+
+// WithMaxIdleConns keeps up to n idle connections to the store open between
+// operations. It has no effect when WithHTTPClient is used.
+func WithMaxIdleConns(n int) Option {
+	return func(c *ShopifyAdminClinetImpl) {
+		c.maxIdleConns = n
+	}
+}
+
+// newTransport returns the transport every operation of a client shares.
+// Every request goes to the same host, so the per-host limit is the limit.
+func newTransport(maxIdleConns int) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConns,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// newHTTPClient returns the HTTP client every operation of s is sent with.
+func (s *ShopifyAdminClinetImpl) newHTTPClient() *http.Client {
+	httpClient := &http.Client{}
+	if s.httpClient != nil {
+		*httpClient = *s.httpClient
+	} else {
+		maxIdleConns := s.maxIdleConns
+		if maxIdleConns <= 0 {
+			maxIdleConns = DefaultMaxIdleConns
+		}
+
+		httpClient.Transport = newTransport(maxIdleConns)
+	}
+
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	httpClient.Transport = &responseTransport{next: next}
+
+	return httpClient
+}
+
+type operationResponseKey struct{}
+
+// operationResponse is the status code, request ID, query cost, deprecation
+// header and deprecation extensions of the response to a single operation.
+type operationResponse struct {
+	statusCode   int
+	requestID    string
+	cost         gjson.Result
+	deprecations []string
+}
+
+// withOperationResponse makes responseTransport capture the response to the
+// request sent with ctx into res.
+func withOperationResponse(ctx context.Context, res *operationResponse) context.Context {
+	return context.WithValue(ctx, operationResponseKey{}, res)
+}
+
+// responseTransport fills in the operationResponse of the request's context,
+// if any.
+type responseTransport struct {
+	next http.RoundTripper
+}
+
+func (t *responseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := req.Context().Value(operationResponseKey{}).(*operationResponse)
+	if !ok {
+		return res, nil
+	}
+
+	r.statusCode = res.StatusCode
+	r.requestID = res.Header.Get(RequestIDHeader)
+
+	for _, reason := range res.Header.Values(DeprecatedReasonHeader) {
+		r.add(reason)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.cost = gjson.GetBytes(body, "extensions.cost")
+
+	gjson.GetBytes(body, "extensions.deprecations").ForEach(func(_, value gjson.Result) bool {
+		switch {
+		case value.Type == gjson.String:
+			r.add(value.String())
+		case value.Get("reason").Exists():
+			r.add(value.Get("reason").String())
+		default:
+			r.add(value.Get("message").String())
+		}
+
+		return true
+	})
+
+	return res, nil
+}
+
+func (r *operationResponse) add(reason string) {
+	reason = strings.TrimSpace(reason)
+	if reason != "" && !slices.Contains(r.deprecations, reason) {
+		r.deprecations = append(r.deprecations, reason)
+	}
+}
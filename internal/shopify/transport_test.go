@@ -0,0 +1,165 @@
+package shopify
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// terraformParallelism is the number of operations Terraform runs at once by
+// default.
+const terraformParallelism = 10
+
+// newTLSTestServer returns a TLS server answering every operation, and a
+// counter of the connections clients opened to it.
+func newTLSTestServer(tb testing.TB) (*httptest.Server, *atomic.Int32) {
+	tb.Helper()
+
+	var connections atomic.Int32
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Stand in for the time Shopify takes to run an operation.
+		time.Sleep(time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"test": "success"}}`))
+	}))
+
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+
+	server.StartTLS()
+	tb.Cleanup(server.Close)
+
+	return server, &connections
+}
+
+// newPooledTestClient returns a client that sends operations to server with
+// its own connection pool, trusting the server's certificate.
+func newPooledTestClient(server *httptest.Server, opts ...Option) *ShopifyAdminClinetImpl {
+	client := New(server.Listener.Addr().String(), "access_token", "2024-07", opts...)
+	client.sharedHTTPClient().Transport.(*responseTransport).next.(*http.Transport).TLSClientConfig = serverTLSConfig(server)
+
+	return client
+}
+
+func serverTLSConfig(server *httptest.Server) *tls.Config {
+	return server.Client().Transport.(*http.Transport).TLSClientConfig
+}
+
+// runConcurrently calls op n times from workers goroutines.
+func runConcurrently(n, workers int, op func()) {
+	var wg sync.WaitGroup
+
+	ops := make(chan struct{}, n)
+	for i := 0; i < n; i++ {
+		ops <- struct{}{}
+	}
+
+	close(ops)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range ops {
+				op()
+
+				// Stand in for the work Terraform does between operations,
+				// which leaves connections idle.
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestConnectionReuse(t *testing.T) {
+	server, connections := newTLSTestServer(t)
+	client := newPooledTestClient(server)
+
+	runConcurrently(200, terraformParallelism, func() {
+		_, err := client.exec(context.Background(), `query { test }`)
+		assert.NoError(t, err)
+	})
+
+	// A connection dialed while another is being returned to the pool can
+	// make the count exceed the parallelism slightly, but not approach one
+	// connection per operation.
+	assert.LessOrEqual(t, connections.Load(), int32(2*terraformParallelism))
+}
+
+func TestWithMaxIdleConns(t *testing.T) {
+	client := New("example.myshopify.com", "access_token", "2024-07", WithMaxIdleConns(3))
+	transport := client.sharedHTTPClient().Transport.(*responseTransport).next.(*http.Transport)
+
+	assert.Equal(t, 3, transport.MaxIdleConns)
+	assert.Equal(t, 3, transport.MaxIdleConnsPerHost)
+
+	client = New("example.myshopify.com", "access_token", "2024-07")
+	transport = client.sharedHTTPClient().Transport.(*responseTransport).next.(*http.Transport)
+
+	assert.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConnsPerHost)
+
+	httpClient := &http.Client{}
+	client = New("example.myshopify.com", "access_token", "2024-07", WithHTTPClient(httpClient), WithMaxIdleConns(3))
+
+	assert.Equal(t, http.DefaultTransport, client.sharedHTTPClient().Transport.(*responseTransport).next)
+}
+
+// BenchmarkExec compares a client and connection pool shared by every
+// operation with creating the clients per operation over
+// http.DefaultTransport. The latter keeps only two idle connections per host,
+// so whenever more operations pause at once their connections are closed and
+// the next operations pay for a new TLS handshake.
+func BenchmarkExec(b *testing.B) {
+	server, connections := newTLSTestServer(b)
+
+	b.Run("PerOperationClient", func(b *testing.B) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = serverTLSConfig(server)
+		defer transport.CloseIdleConnections()
+
+		connections.Store(0)
+		b.ResetTimer()
+
+		runConcurrently(b.N, terraformParallelism, func() {
+			client := New(server.Listener.Addr().String(), "access_token", "2024-07", WithHTTPClient(&http.Client{Transport: transport}))
+
+			_, err := client.exec(context.Background(), `query { test }`)
+			require.NoError(b, err)
+		})
+
+		b.ReportMetric(float64(connections.Load()), "conns")
+	})
+
+	b.Run("SharedClient", func(b *testing.B) {
+		client := newPooledTestClient(server)
+		defer client.sharedHTTPClient().CloseIdleConnections()
+
+		connections.Store(0)
+		b.ResetTimer()
+
+		runConcurrently(b.N, terraformParallelism, func() {
+			_, err := client.exec(context.Background(), `query { test }`)
+			require.NoError(b, err)
+		})
+
+		b.ReportMetric(float64(connections.Load()), "conns")
+	})
+}
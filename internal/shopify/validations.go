@@ -0,0 +1,63 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/tidwall/gjson"
+)
+
+var _ validationService = (*validationServiceImpl)(nil)
+
+type validationService interface {
+	List(ctx context.Context) ([]Validation, error)
+}
+
+type validationServiceImpl struct {
+	client shopifyAdminClient
+}
+
+// Validation is a checkout validation, backed by a function that implements
+// the Cart and Checkout Validation Function API.
+type Validation struct {
+	ID         string
+	FunctionID string
+	Title      string
+	Enabled    bool
+}
+
+func (v *validationServiceImpl) List(ctx context.Context) ([]Validation, error) {
+	gql := `
+		query {
+			validations(first: 250%s) {
+				nodes {
+					id
+					title
+					enabled
+					shopifyFunction {
+						id
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	validations := []Validation{}
+	err := listConnection(ctx, v.client, gql, "validations", "", func(node gjson.Result) {
+		validations = append(validations, Validation{
+			ID:         node.Get("id").String(),
+			FunctionID: node.Get("shopifyFunction.id").String(),
+			Title:      node.Get("title").String(),
+			Enabled:    node.Get("enabled").Bool(),
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return validations, nil
+}
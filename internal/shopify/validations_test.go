@@ -0,0 +1,50 @@
+package shopify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidationService_List(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &validationServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	expectedResponse := map[string]interface{}{
+		"validations": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":      "gid://shopify/Validation/1",
+					"title":   "Minimum quantity",
+					"enabled": true,
+					"shopifyFunction": map[string]interface{}{
+						"id": "0b4b1b6e-2f52-4b64-9f3c-0f8b0c6a8c11",
+					},
+				},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(expectedResponse, nil)
+
+	validations, err := service.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Validation{
+		{
+			ID:         "gid://shopify/Validation/1",
+			FunctionID: "0b4b1b6e-2f52-4b64-9f3c-0f8b0c6a8c11",
+			Title:      "Minimum quantity",
+			Enabled:    true,
+		},
+	}, validations)
+
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,119 @@
+package shopify
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// apiVersions lists, oldest first, the Admin API versions whose schemas the
+// operations in this package are validated against.
+var apiVersions = []string{
+	"2026-01",
+	"2026-04",
+	"2026-07",
+	"2026-10",
+}
+
+// apiVersionSupportMonths is how long Shopify supports a stable API version
+// after its release.
+const apiVersionSupportMonths = 12
+
+// Feature is a part of the Admin API that only exists from some version on.
+type Feature string
+
+const (
+	// FeatureFunctionHandle lets function-backed discounts and customizations
+	// reference their function by handle, and exposes ShopifyFunction.handle.
+	FeatureFunctionHandle Feature = "functionHandle"
+)
+
+var featureVersions = map[Feature]string{
+	FeatureFunctionHandle: "2025-04",
+}
+
+type ApiVersionSupport int
+
+const (
+	// ApiVersionSupported is a version within Shopify's support window that
+	// the provider has been validated against.
+	ApiVersionSupported ApiVersionSupport = iota
+	// ApiVersionUnsupported is a version past Shopify's support window.
+	// Shopify answers requests for it with the oldest supported version.
+	ApiVersionUnsupported
+	// ApiVersionUntested is a released version newer than any the provider
+	// has been validated against.
+	ApiVersionUntested
+)
+
+// ApiVersions returns the Admin API versions the provider has been validated
+// against, oldest first.
+func ApiVersions() []string {
+	return slices.Clone(apiVersions)
+}
+
+// SupportsFeature reports whether apiVersion includes feature.
+func SupportsFeature(apiVersion string, feature Feature) bool {
+	since, ok := featureVersions[feature]
+	return ok && apiVersion >= since
+}
+
+// FeatureVersion returns the first API version that includes feature.
+func FeatureVersion(feature Feature) string {
+	return featureVersions[feature]
+}
+
+// Supports reports whether the client's API version includes feature.
+func (s *ShopifyAdminClinetImpl) Supports(feature Feature) bool {
+	return SupportsFeature(s.storeApiVersion, feature)
+}
+
+// CheckApiVersion reports how well apiVersion is supported at now. It returns
+// an error for versions Shopify has not released.
+func CheckApiVersion(apiVersion string, now time.Time) (ApiVersionSupport, error) {
+	released, err := apiVersionReleaseDate(apiVersion)
+	if err != nil {
+		return ApiVersionUnsupported, err
+	}
+
+	if released.After(now) {
+		return ApiVersionUnsupported, fmt.Errorf("shopify API version %s has not been released yet", apiVersion)
+	}
+
+	if !now.Before(released.AddDate(0, apiVersionSupportMonths, 0)) {
+		return ApiVersionUnsupported, nil
+	}
+
+	if apiVersion > apiVersions[len(apiVersions)-1] {
+		return ApiVersionUntested, nil
+	}
+
+	return ApiVersionSupported, nil
+}
+
+// ApiVersionSupportEnds returns when Shopify stops supporting apiVersion, or
+// the zero time if apiVersion is not a Shopify API version.
+func ApiVersionSupportEnds(apiVersion string) time.Time {
+	released, err := apiVersionReleaseDate(apiVersion)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return released.AddDate(0, apiVersionSupportMonths, 0)
+}
+
+func apiVersionReleaseDate(apiVersion string) (time.Time, error) {
+	released, err := time.Parse("2006-01", apiVersion)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a Shopify API version, expected YYYY-MM", apiVersion)
+	}
+
+	if (released.Month()-1)%3 != 0 {
+		return time.Time{}, fmt.Errorf(
+			"%q is not a Shopify API version, versions are released quarterly in January, April, July and October",
+			apiVersion,
+		)
+	}
+
+	return released, nil
+}
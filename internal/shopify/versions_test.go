@@ -0,0 +1,74 @@
+package shopify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportsFeature(t *testing.T) {
+	assert.False(t, SupportsFeature("2024-07", FeatureFunctionHandle))
+	assert.False(t, SupportsFeature("2025-01", FeatureFunctionHandle))
+	assert.True(t, SupportsFeature("2025-04", FeatureFunctionHandle))
+	assert.True(t, SupportsFeature("2025-07", FeatureFunctionHandle))
+	assert.False(t, SupportsFeature("2025-07", Feature("unknown")))
+
+	assert.True(t, New("example.myshopify.com", "token", "2025-04").Supports(FeatureFunctionHandle))
+}
+
+func TestFeatureVersion(t *testing.T) {
+	assert.Equal(t, "2025-04", FeatureVersion(FeatureFunctionHandle))
+	assert.Empty(t, FeatureVersion(Feature("unknown")))
+}
+
+func TestApiVersions(t *testing.T) {
+	versions := ApiVersions()
+
+	assert.IsIncreasing(t, versions)
+
+	versions[0] = "changed"
+	assert.NotEqual(t, "changed", ApiVersions()[0])
+}
+
+func TestCheckApiVersion(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Supported", func(t *testing.T) {
+		support, err := CheckApiVersion("2026-01", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ApiVersionSupported, support)
+	})
+
+	t.Run("Past support window", func(t *testing.T) {
+		support, err := CheckApiVersion("2025-01", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ApiVersionUnsupported, support)
+		assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), ApiVersionSupportEnds("2025-01"))
+	})
+
+	t.Run("Newer than validated versions", func(t *testing.T) {
+		support, err := CheckApiVersion("2027-01", time.Date(2027, time.February, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.NoError(t, err)
+		assert.Equal(t, ApiVersionUntested, support)
+	})
+
+	t.Run("Invalid versions", func(t *testing.T) {
+		for _, version := range []string{"2025-04-01", "latest", "2024-13", "2024-02"} {
+			_, err := CheckApiVersion(version, now)
+
+			assert.Error(t, err, version)
+		}
+
+		assert.True(t, ApiVersionSupportEnds("2024-02").IsZero())
+	})
+
+	t.Run("Unreleased", func(t *testing.T) {
+		_, err := CheckApiVersion("2026-04", now)
+
+		assert.ErrorContains(t, err, "has not been released yet")
+	})
+}
@@ -0,0 +1,136 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+var _ webhookSubscriptionService = (*webhookSubscriptionServiceImpl)(nil)
+
+type webhookSubscriptionService interface {
+	List(ctx context.Context, filter *WebhookSubscriptionFilter) ([]WebhookSubscription, error)
+}
+
+type webhookSubscriptionServiceImpl struct {
+	client shopifyAdminClient
+}
+
+const (
+	WebhookEndpointHTTP        = "HTTP"
+	WebhookEndpointEventBridge = "EVENT_BRIDGE"
+	WebhookEndpointPubSub      = "PUBSUB"
+)
+
+type WebhookSubscriptionFilter struct {
+	Topics      []string
+	CallbackURL string
+	Format      string
+}
+
+type WebhookSubscription struct {
+	ID            string
+	Topic         string
+	Format        string
+	EndpointType  string
+	CallbackURL   string
+	Arn           string
+	PubSubProject string
+	PubSubTopic   string
+}
+
+// webhookSubscriptionFragment selects a webhook subscription and whichever
+// kind of endpoint it delivers to, as webhookSubscription reads it.
+const webhookSubscriptionFragment = `
+	id
+	topic
+	format
+	endpoint {
+		__typename
+		... on WebhookHttpEndpoint {
+			callbackUrl
+		}
+		... on WebhookEventBridgeEndpoint {
+			arn
+		}
+		... on WebhookPubSubEndpoint {
+			pubSubProject
+			pubSubTopic
+		}
+	}
+`
+
+// webhookSubscription reads a webhook subscription selected with
+// webhookSubscriptionFragment.
+func webhookSubscription(json gjson.Result) WebhookSubscription {
+	return WebhookSubscription{
+		ID:            json.Get("id").String(),
+		Topic:         json.Get("topic").String(),
+		Format:        json.Get("format").String(),
+		EndpointType:  webhookEndpointType(json.Get("endpoint.__typename").String()),
+		CallbackURL:   json.Get("endpoint.callbackUrl").String(),
+		Arn:           json.Get("endpoint.arn").String(),
+		PubSubProject: json.Get("endpoint.pubSubProject").String(),
+		PubSubTopic:   json.Get("endpoint.pubSubTopic").String(),
+	}
+}
+
+func (w *webhookSubscriptionServiceImpl) List(
+	ctx context.Context,
+	filter *WebhookSubscriptionFilter,
+) ([]WebhookSubscription, error) {
+	gql := `
+		query {
+			webhookSubscriptions(first: 250%s) {
+				nodes {
+					` + webhookSubscriptionFragment + `
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	args := ""
+	if filter != nil {
+		if len(filter.Topics) > 0 {
+			args += fmt.Sprintf(", topics: [%s]", strings.Join(filter.Topics, ", "))
+		}
+
+		if filter.CallbackURL != "" {
+			args += fmt.Sprintf(`, callbackUrl: "%s"`, filter.CallbackURL)
+		}
+
+		if filter.Format != "" {
+			args += fmt.Sprintf(", format: %s", filter.Format)
+		}
+	}
+
+	subscriptions := []WebhookSubscription{}
+	err := listConnection(ctx, w.client, gql, "webhookSubscriptions", args, func(node gjson.Result) {
+		subscriptions = append(subscriptions, webhookSubscription(node))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func webhookEndpointType(typename string) string {
+	switch typename {
+	case "WebhookHttpEndpoint":
+		return WebhookEndpointHTTP
+	case "WebhookEventBridgeEndpoint":
+		return WebhookEndpointEventBridge
+	case "WebhookPubSubEndpoint":
+		return WebhookEndpointPubSub
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,144 @@
+package shopify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookSubscriptionService_List(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &webhookSubscriptionServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	firstPage := map[string]interface{}{
+		"webhookSubscriptions": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":     "gid://shopify/WebhookSubscription/1",
+					"topic":  "ORDERS_CREATE",
+					"format": "JSON",
+					"endpoint": map[string]interface{}{
+						"__typename":  "WebhookHttpEndpoint",
+						"callbackUrl": "https://example.com/webhooks",
+					},
+				},
+				map[string]interface{}{
+					"id":     "gid://shopify/WebhookSubscription/2",
+					"topic":  "ORDERS_PAID",
+					"format": "JSON",
+					"endpoint": map[string]interface{}{
+						"__typename": "WebhookEventBridgeEndpoint",
+						"arn":        "arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1/source",
+					},
+				},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": true,
+				"endCursor":   "cursor-1",
+			},
+		},
+	}
+
+	secondPage := map[string]interface{}{
+		"webhookSubscriptions": map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"id":     "gid://shopify/WebhookSubscription/3",
+					"topic":  "DISCOUNTS_CREATE",
+					"format": "JSON",
+					"endpoint": map[string]interface{}{
+						"__typename":    "WebhookPubSubEndpoint",
+						"pubSubProject": "test-project",
+						"pubSubTopic":   "test-topic",
+					},
+				},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+				"endCursor":   "cursor-2",
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return !strings.Contains(q, "after:")
+	})).Return(firstPage, nil).Once()
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, `after: "cursor-1"`)
+	})).Return(secondPage, nil).Once()
+
+	subscriptions, err := service.List(ctx, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, subscriptions, 3)
+
+	assert.Equal(t, "gid://shopify/WebhookSubscription/1", subscriptions[0].ID)
+	assert.Equal(t, WebhookEndpointHTTP, subscriptions[0].EndpointType)
+	assert.Equal(t, "https://example.com/webhooks", subscriptions[0].CallbackURL)
+
+	assert.Equal(t, WebhookEndpointEventBridge, subscriptions[1].EndpointType)
+	assert.Equal(t, "arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1/source", subscriptions[1].Arn)
+
+	assert.Equal(t, "DISCOUNTS_CREATE", subscriptions[2].Topic)
+	assert.Equal(t, WebhookEndpointPubSub, subscriptions[2].EndpointType)
+	assert.Equal(t, "test-project", subscriptions[2].PubSubProject)
+	assert.Equal(t, "test-topic", subscriptions[2].PubSubTopic)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestWebhookSubscriptionService_ListFilter(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &webhookSubscriptionServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	expectedResponse := map[string]interface{}{
+		"webhookSubscriptions": map[string]interface{}{
+			"nodes": []interface{}{},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage": false,
+			},
+		},
+	}
+
+	mockClient.On("exec", ctx, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "topics: [ORDERS_CREATE, ORDERS_PAID]") &&
+			strings.Contains(q, `callbackUrl: "https://example.com/webhooks"`) &&
+			strings.Contains(q, "format: JSON")
+	})).Return(expectedResponse, nil).Once()
+
+	subscriptions, err := service.List(ctx, &WebhookSubscriptionFilter{
+		Topics:      []string{"ORDERS_CREATE", "ORDERS_PAID"},
+		CallbackURL: "https://example.com/webhooks",
+		Format:      "JSON",
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, subscriptions)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestWebhookSubscriptionService_ListError(t *testing.T) {
+	mockClient := new(mockShopifyAdminClient)
+	service := &webhookSubscriptionServiceImpl{client: mockClient}
+
+	ctx := context.Background()
+
+	mockClient.On("exec", ctx, mock.AnythingOfType("string")).Return(nil, assert.AnError)
+
+	subscriptions, err := service.List(ctx, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, subscriptions)
+	assert.Equal(t, assert.AnError, err)
+
+	mockClient.AssertExpectations(t)
+}
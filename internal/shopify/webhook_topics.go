@@ -0,0 +1,382 @@
+package shopify
+
+import (
+	"sort"
+	"strings"
+)
+
+type WebhookTopic struct {
+	Name              string
+	Deprecated        bool
+	DeprecationReason string
+}
+
+type webhookTopicRelease struct {
+	version    string
+	added      []string
+	deprecated map[string]string
+	removed    []string
+}
+
+// webhookTopicReleases mirrors the WebhookSubscriptionTopic enum of the Admin
+// API. Every release only records what changed since the previous one, so the
+// entries must stay sorted by version.
+var webhookTopicReleases = []webhookTopicRelease{
+	{
+		version: "2024-01",
+		added: []string{
+			"APP_PURCHASES_ONE_TIME_UPDATE",
+			"APP_SUBSCRIPTIONS_APPROACHING_CAPPED_AMOUNT",
+			"APP_SUBSCRIPTIONS_UPDATE",
+			"APP_UNINSTALLED",
+			"ATTRIBUTED_SESSIONS_FIRST",
+			"ATTRIBUTED_SESSIONS_LAST",
+			"AUDIT_EVENTS_ADMIN_API_ACTIVITY",
+			"BULK_OPERATIONS_FINISH",
+			"CARTS_CREATE",
+			"CARTS_UPDATE",
+			"CHANNELS_DELETE",
+			"CHECKOUTS_CREATE",
+			"CHECKOUTS_DELETE",
+			"CHECKOUTS_UPDATE",
+			"COLLECTIONS_CREATE",
+			"COLLECTIONS_DELETE",
+			"COLLECTIONS_UPDATE",
+			"COLLECTION_LISTINGS_ADD",
+			"COLLECTION_LISTINGS_REMOVE",
+			"COLLECTION_LISTINGS_UPDATE",
+			"COLLECTION_PUBLICATIONS_CREATE",
+			"COLLECTION_PUBLICATIONS_DELETE",
+			"COLLECTION_PUBLICATIONS_UPDATE",
+			"COMPANIES_CREATE",
+			"COMPANIES_DELETE",
+			"COMPANIES_UPDATE",
+			"COMPANY_CONTACTS_CREATE",
+			"COMPANY_CONTACTS_DELETE",
+			"COMPANY_CONTACTS_UPDATE",
+			"COMPANY_LOCATIONS_CREATE",
+			"COMPANY_LOCATIONS_DELETE",
+			"COMPANY_LOCATIONS_UPDATE",
+			"CUSTOMERS_CREATE",
+			"CUSTOMERS_DELETE",
+			"CUSTOMERS_DISABLE",
+			"CUSTOMERS_EMAIL_MARKETING_CONSENT_UPDATE",
+			"CUSTOMERS_ENABLE",
+			"CUSTOMERS_MARKETING_CONSENT_UPDATE",
+			"CUSTOMERS_MERGE",
+			"CUSTOMERS_UPDATE",
+			"CUSTOMER_GROUPS_CREATE",
+			"CUSTOMER_GROUPS_DELETE",
+			"CUSTOMER_GROUPS_UPDATE",
+			"CUSTOMER_PAYMENT_METHODS_CREATE",
+			"CUSTOMER_PAYMENT_METHODS_REVOKE",
+			"CUSTOMER_PAYMENT_METHODS_UPDATE",
+			"DISCOUNTS_CREATE",
+			"DISCOUNTS_DELETE",
+			"DISCOUNTS_REDEEMCODE_ADDED",
+			"DISCOUNTS_REDEEMCODE_REMOVED",
+			"DISCOUNTS_UPDATE",
+			"DISPUTES_CREATE",
+			"DISPUTES_UPDATE",
+			"DOMAINS_CREATE",
+			"DOMAINS_DESTROY",
+			"DOMAINS_UPDATE",
+			"DRAFT_ORDERS_CREATE",
+			"DRAFT_ORDERS_DELETE",
+			"DRAFT_ORDERS_UPDATE",
+			"FULFILLMENTS_CREATE",
+			"FULFILLMENTS_UPDATE",
+			"FULFILLMENT_EVENTS_CREATE",
+			"FULFILLMENT_EVENTS_DELETE",
+			"FULFILLMENT_ORDERS_CANCELLATION_REQUEST_ACCEPTED",
+			"FULFILLMENT_ORDERS_CANCELLATION_REQUEST_REJECTED",
+			"FULFILLMENT_ORDERS_CANCELLATION_REQUEST_SUBMITTED",
+			"FULFILLMENT_ORDERS_CANCELLED",
+			"FULFILLMENT_ORDERS_FULFILLMENT_REQUEST_ACCEPTED",
+			"FULFILLMENT_ORDERS_FULFILLMENT_REQUEST_REJECTED",
+			"FULFILLMENT_ORDERS_FULFILLMENT_REQUEST_SUBMITTED",
+			"FULFILLMENT_ORDERS_FULFILLMENT_SERVICE_FAILED_TO_COMPLETE",
+			"FULFILLMENT_ORDERS_HOLD_RELEASED",
+			"FULFILLMENT_ORDERS_LINE_ITEMS_PREPARED_FOR_LOCAL_DELIVERY",
+			"FULFILLMENT_ORDERS_LINE_ITEMS_PREPARED_FOR_PICKUP",
+			"FULFILLMENT_ORDERS_MERGED",
+			"FULFILLMENT_ORDERS_MOVED",
+			"FULFILLMENT_ORDERS_ORDER_ROUTING_COMPLETE",
+			"FULFILLMENT_ORDERS_PLACED_ON_HOLD",
+			"FULFILLMENT_ORDERS_RESCHEDULED",
+			"FULFILLMENT_ORDERS_SCHEDULED_FULFILLMENT_ORDER_READY",
+			"FULFILLMENT_ORDERS_SPLIT",
+			"INVENTORY_ITEMS_CREATE",
+			"INVENTORY_ITEMS_DELETE",
+			"INVENTORY_ITEMS_UPDATE",
+			"INVENTORY_LEVELS_CONNECT",
+			"INVENTORY_LEVELS_DISCONNECT",
+			"INVENTORY_LEVELS_UPDATE",
+			"LOCALES_CREATE",
+			"LOCALES_UPDATE",
+			"LOCATIONS_ACTIVATE",
+			"LOCATIONS_CREATE",
+			"LOCATIONS_DEACTIVATE",
+			"LOCATIONS_DELETE",
+			"LOCATIONS_UPDATE",
+			"MARKETS_CREATE",
+			"MARKETS_DELETE",
+			"MARKETS_UPDATE",
+			"ORDERS_CANCELLED",
+			"ORDERS_CREATE",
+			"ORDERS_DELETE",
+			"ORDERS_EDITED",
+			"ORDERS_FULFILLED",
+			"ORDERS_PAID",
+			"ORDERS_PARTIALLY_FULFILLED",
+			"ORDERS_UPDATED",
+			"ORDER_TRANSACTIONS_CREATE",
+			"PAYMENT_SCHEDULES_DUE",
+			"PAYMENT_TERMS_CREATE",
+			"PAYMENT_TERMS_DELETE",
+			"PAYMENT_TERMS_UPDATE",
+			"PRODUCTS_CREATE",
+			"PRODUCTS_DELETE",
+			"PRODUCTS_UPDATE",
+			"PRODUCT_LISTINGS_ADD",
+			"PRODUCT_LISTINGS_REMOVE",
+			"PRODUCT_LISTINGS_UPDATE",
+			"PRODUCT_PUBLICATIONS_CREATE",
+			"PRODUCT_PUBLICATIONS_DELETE",
+			"PRODUCT_PUBLICATIONS_UPDATE",
+			"PROFILES_CREATE",
+			"PROFILES_DELETE",
+			"PROFILES_UPDATE",
+			"REFUNDS_CREATE",
+			"RETURNS_APPROVE",
+			"RETURNS_CANCEL",
+			"RETURNS_CLOSE",
+			"RETURNS_DECLINE",
+			"RETURNS_REOPEN",
+			"RETURNS_REQUEST",
+			"REVERSE_DELIVERIES_ATTACH_DELIVERABLE",
+			"REVERSE_FULFILLMENT_ORDERS_DISPOSE",
+			"SCHEDULED_PRODUCT_LISTINGS_ADD",
+			"SCHEDULED_PRODUCT_LISTINGS_REMOVE",
+			"SCHEDULED_PRODUCT_LISTINGS_UPDATE",
+			"SEGMENTS_CREATE",
+			"SEGMENTS_DELETE",
+			"SEGMENTS_UPDATE",
+			"SELLING_PLAN_GROUPS_CREATE",
+			"SELLING_PLAN_GROUPS_DELETE",
+			"SELLING_PLAN_GROUPS_UPDATE",
+			"SHIPPING_ADDRESSES_CREATE",
+			"SHIPPING_ADDRESSES_UPDATE",
+			"SHOP_UPDATE",
+			"SUBSCRIPTION_BILLING_ATTEMPTS_CHALLENGED",
+			"SUBSCRIPTION_BILLING_ATTEMPTS_FAILURE",
+			"SUBSCRIPTION_BILLING_ATTEMPTS_SUCCESS",
+			"SUBSCRIPTION_BILLING_CYCLE_EDITS_CREATE",
+			"SUBSCRIPTION_BILLING_CYCLE_EDITS_DELETE",
+			"SUBSCRIPTION_BILLING_CYCLE_EDITS_UPDATE",
+			"SUBSCRIPTION_CONTRACTS_ACTIVATE",
+			"SUBSCRIPTION_CONTRACTS_CANCEL",
+			"SUBSCRIPTION_CONTRACTS_CREATE",
+			"SUBSCRIPTION_CONTRACTS_EXPIRE",
+			"SUBSCRIPTION_CONTRACTS_FAIL",
+			"SUBSCRIPTION_CONTRACTS_PAUSE",
+			"SUBSCRIPTION_CONTRACTS_UPDATE",
+			"TAX_SERVICES_CREATE",
+			"TAX_SERVICES_UPDATE",
+			"TAX_SUMMARIES_CREATE",
+			"TENDER_TRANSACTIONS_CREATE",
+			"THEMES_CREATE",
+			"THEMES_DELETE",
+			"THEMES_PUBLISH",
+			"THEMES_UPDATE",
+			"VARIANTS_IN_STOCK",
+			"VARIANTS_OUT_OF_STOCK",
+		},
+	},
+	{
+		version: "2024-04",
+		added: []string{
+			"PRODUCT_FEEDS_CREATE",
+			"PRODUCT_FEEDS_FULL_SYNC",
+			"PRODUCT_FEEDS_INCREMENTAL_SYNC",
+			"PRODUCT_FEEDS_UPDATE",
+		},
+		deprecated: map[string]string{
+			"PRODUCT_LISTINGS_ADD":    "Use PRODUCT_FEEDS_INCREMENTAL_SYNC instead.",
+			"PRODUCT_LISTINGS_REMOVE": "Use PRODUCT_FEEDS_INCREMENTAL_SYNC instead.",
+			"PRODUCT_LISTINGS_UPDATE": "Use PRODUCT_FEEDS_INCREMENTAL_SYNC instead.",
+		},
+	},
+	{
+		version: "2024-07",
+		added: []string{
+			"CUSTOMER_ACCOUNT_SETTINGS_UPDATE",
+			"METAOBJECTS_CREATE",
+			"METAOBJECTS_DELETE",
+			"METAOBJECTS_UPDATE",
+			"RETURNS_PROCESS",
+		},
+		deprecated: map[string]string{
+			"CUSTOMERS_MARKETING_CONSENT_UPDATE": "Use CUSTOMERS_EMAIL_MARKETING_CONSENT_UPDATE instead.",
+			"SCHEDULED_PRODUCT_LISTINGS_ADD":     "Scheduled publishing is reported through PRODUCT_PUBLICATIONS_UPDATE.",
+			"SCHEDULED_PRODUCT_LISTINGS_REMOVE":  "Scheduled publishing is reported through PRODUCT_PUBLICATIONS_UPDATE.",
+			"SCHEDULED_PRODUCT_LISTINGS_UPDATE":  "Scheduled publishing is reported through PRODUCT_PUBLICATIONS_UPDATE.",
+		},
+	},
+	{
+		version: "2024-10",
+		added: []string{
+			"CUSTOMER_TAGS_ADDED",
+			"CUSTOMER_TAGS_REMOVED",
+			"DELIVERY_PROMISE_SETTINGS_UPDATE",
+			"FINANCE_KYC_INFORMATION_UPDATE",
+		},
+		removed: []string{
+			"SCHEDULED_PRODUCT_LISTINGS_ADD",
+			"SCHEDULED_PRODUCT_LISTINGS_REMOVE",
+			"SCHEDULED_PRODUCT_LISTINGS_UPDATE",
+		},
+	},
+}
+
+// WebhookTopicVersions returns the API versions the webhook topic enum is
+// known for, oldest first.
+func WebhookTopicVersions() []string {
+	versions := make([]string, 0, len(webhookTopicReleases))
+	for _, release := range webhookTopicReleases {
+		versions = append(versions, release.version)
+	}
+
+	return versions
+}
+
+// WebhookTopicsKnown reports whether apiVersion is no newer than the last
+// release in the registry. The enum of later versions resolves to that
+// release, which lacks the topics Shopify added since.
+func WebhookTopicsKnown(apiVersion string) bool {
+	return apiVersion <= webhookTopicReleases[len(webhookTopicReleases)-1].version
+}
+
+// WebhookTopics returns the WebhookSubscriptionTopic enum for apiVersion.
+// Versions that are not shipped with the provider resolve to the closest
+// earlier release, or to the oldest one when apiVersion predates them all.
+func WebhookTopics(apiVersion string) map[string]WebhookTopic {
+	topics := map[string]WebhookTopic{}
+
+	for i, release := range webhookTopicReleases {
+		if i > 0 && release.version > apiVersion {
+			break
+		}
+
+		for _, name := range release.added {
+			topics[name] = WebhookTopic{Name: name}
+		}
+
+		for name, reason := range release.deprecated {
+			topics[name] = WebhookTopic{
+				Name:              name,
+				Deprecated:        true,
+				DeprecationReason: reason,
+			}
+		}
+
+		for _, name := range release.removed {
+			delete(topics, name)
+		}
+	}
+
+	return topics
+}
+
+// webhookTopicAccessScopes maps the prefix of a webhook topic, which names
+// the resource it is about, to the access scope Shopify requires to subscribe
+// to it. Topics of other resources, like APP_UNINSTALLED or SHOP_UPDATE, need
+// no scope or one Shopify doesn't derive from the topic alone, and aren't
+// listed.
+var webhookTopicAccessScopes = map[string]string{
+	"CHECKOUTS_":          "read_orders",
+	"COLLECTIONS_":        "read_products",
+	"CUSTOMERS_":          "read_customers",
+	"DISCOUNTS_":          "read_discounts",
+	"DRAFT_ORDERS_":       "read_draft_orders",
+	"INVENTORY_ITEMS_":    "read_inventory",
+	"INVENTORY_LEVELS_":   "read_inventory",
+	"LOCATIONS_":          "read_locations",
+	"ORDERS_":             "read_orders",
+	"ORDER_TRANSACTIONS_": "read_orders",
+	"PRODUCTS_":           "read_products",
+	"REFUNDS_":            "read_orders",
+	"THEMES_":             "read_themes",
+}
+
+// WebhookTopicAccessScopes returns the access scopes the app needs to
+// subscribe to topic, or nil when the topic's scope is not known.
+func WebhookTopicAccessScopes(topic string) []string {
+	for prefix, scope := range webhookTopicAccessScopes {
+		if strings.HasPrefix(topic, prefix) {
+			return []string{scope}
+		}
+	}
+
+	return nil
+}
+
+// SuggestWebhookTopics returns up to three topics of apiVersion that are close
+// to the given, unknown topic.
+func SuggestWebhookTopics(apiVersion string, topic string) []string {
+	topic = strings.ToUpper(strings.TrimSpace(topic))
+	maxDistance := len(topic)/4 + 1
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	var candidates []candidate
+	for name := range WebhookTopics(apiVersion) {
+		distance := levenshtein(topic, name)
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{name, distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,88 @@
+package shopify
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookTopics(t *testing.T) {
+	t.Run("Known version", func(t *testing.T) {
+		topics := WebhookTopics("2024-04")
+
+		assert.Contains(t, topics, "ORDERS_CREATE")
+		assert.Contains(t, topics, "PRODUCT_FEEDS_CREATE")
+		assert.NotContains(t, topics, "RETURNS_PROCESS")
+		assert.True(t, topics["PRODUCT_LISTINGS_ADD"].Deprecated)
+		assert.NotEmpty(t, topics["PRODUCT_LISTINGS_ADD"].DeprecationReason)
+		assert.False(t, topics["ORDERS_CREATE"].Deprecated)
+	})
+
+	t.Run("Removed topics", func(t *testing.T) {
+		assert.Contains(t, WebhookTopics("2024-07"), "SCHEDULED_PRODUCT_LISTINGS_ADD")
+		assert.NotContains(t, WebhookTopics("2024-10"), "SCHEDULED_PRODUCT_LISTINGS_ADD")
+	})
+
+	t.Run("Unknown version resolves to closest earlier release", func(t *testing.T) {
+		assert.Equal(t, WebhookTopics("2024-07"), WebhookTopics("2024-09"))
+		assert.Equal(t, WebhookTopics("2024-10"), WebhookTopics("2025-01"))
+	})
+
+	t.Run("Version before the oldest release", func(t *testing.T) {
+		assert.Equal(t, WebhookTopics("2024-01"), WebhookTopics("2023-04"))
+	})
+}
+
+func TestWebhookTopicVersions(t *testing.T) {
+	versions := WebhookTopicVersions()
+
+	assert.Equal(t, "2024-01", versions[0])
+	assert.IsIncreasing(t, versions)
+}
+
+func TestWebhookTopicsKnown(t *testing.T) {
+	assert.True(t, WebhookTopicsKnown("2024-01"))
+	assert.True(t, WebhookTopicsKnown("2024-10"))
+	assert.False(t, WebhookTopicsKnown("2025-01"))
+}
+
+func TestSuggestWebhookTopics(t *testing.T) {
+	t.Run("Near miss", func(t *testing.T) {
+		suggestions := SuggestWebhookTopics("2024-07", "ORDER_CREATE")
+
+		assert.Equal(t, "ORDERS_CREATE", suggestions[0])
+		assert.LessOrEqual(t, len(suggestions), 3)
+	})
+
+	t.Run("Lower case", func(t *testing.T) {
+		assert.Contains(t, SuggestWebhookTopics("2024-07", "discounts_create"), "DISCOUNTS_CREATE")
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		assert.Empty(t, SuggestWebhookTopics("2024-07", "SOMETHING_ELSE_ENTIRELY"))
+	})
+}
+
+func TestWebhookTopicAccessScopes(t *testing.T) {
+	assert.Equal(t, []string{"read_orders"}, WebhookTopicAccessScopes("ORDERS_CREATE"))
+	assert.Equal(t, []string{"read_draft_orders"}, WebhookTopicAccessScopes("DRAFT_ORDERS_CREATE"))
+	assert.Equal(t, []string{"read_discounts"}, WebhookTopicAccessScopes("DISCOUNTS_REDEEMCODE_ADDED"))
+	assert.Nil(t, WebhookTopicAccessScopes("APP_UNINSTALLED"))
+
+	topics := WebhookTopics(webhookTopicReleases[len(webhookTopicReleases)-1].version)
+	for prefix := range webhookTopicAccessScopes {
+		assert.True(t, slices.ContainsFunc(slices.Collect(maps.Keys(topics)), func(name string) bool {
+			return strings.HasPrefix(name, prefix)
+		}), "no topic starts with %s", prefix)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("ORDERS_PAID", "ORDERS_PAID"))
+	assert.Equal(t, 1, levenshtein("ORDER_PAID", "ORDERS_PAID"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 5, levenshtein("", "THEME"))
+}
@@ -0,0 +1,306 @@
+package shopifytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// ModeRecord sends requests to the real store and records every
+	// request/response pair.
+	ModeRecord Mode = iota
+	// ModeReplay serves responses from a recorded cassette and never touches
+	// the network.
+	ModeReplay
+)
+
+const redacted = "[REDACTED]"
+
+// redactedFields are JSON fields that hold credentials, such as those sent to
+// and returned by the OAuth token endpoint. Their values are never recorded.
+var redactedFields = []string{"access_token", "client_secret", "subject_token"}
+
+// Cassette is the on-disk form of a recording.
+type Cassette struct {
+	ApiVersion   string        `json:"api_version,omitempty"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body"`
+}
+
+type CassetteResponse struct {
+	StatusCode int             `json:"status_code"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records Admin API traffic to a
+// cassette file, or replays it from one.
+type Recorder struct {
+	path    string
+	mode    Mode
+	next    http.RoundTripper
+	secrets []string
+
+	mu        sync.Mutex
+	cassette  Cassette
+	used      []bool
+	unmatched []string
+}
+
+// NewRecorder opens the cassette at path. In ModeRecord requests are sent
+// through next, which defaults to http.DefaultTransport, and every occurrence
+// of secrets is scrubbed before it is written. Request headers are never
+// recorded. In ModeReplay the cassette must already exist.
+func NewRecorder(path string, mode Mode, next http.RoundTripper, secrets ...string) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path: path,
+		mode: mode,
+		next: next,
+	}
+
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+		}
+
+		// The cassette is written indented; compact the bodies again so they
+		// compare equal to live requests.
+		for i := range r.cassette.Interactions {
+			request := &r.cassette.Interactions[i].Request
+			request.Body = rawJSON(string(request.Body))
+		}
+
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an HTTP client that sends its requests through the
+// recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// ApiVersion returns the API version the cassette was recorded against.
+func (r *Recorder) ApiVersion() string {
+	return r.cassette.ApiVersion
+}
+
+// SetApiVersion records the API version the cassette is recorded against.
+func (r *Recorder) SetApiVersion(version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.ApiVersion = version
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	res, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			Path:   r.scrub(req.URL.Path),
+			Body:   redactFields(rawJSON(r.scrub(string(body)))),
+		},
+		Response: CassetteResponse{
+			StatusCode: res.StatusCode,
+			Body:       redactFields(rawJSON(r.scrub(string(resBody)))),
+		},
+	})
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reqBody := redactFields(rawJSON(r.scrub(string(body))))
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] ||
+			interaction.Request.Method != req.Method ||
+			interaction.Request.Path != req.URL.Path ||
+			!bytes.Equal(interaction.Request.Body, reqBody) {
+			continue
+		}
+
+		r.used[i] = true
+
+		return &http.Response{
+			StatusCode: interaction.Response.StatusCode,
+			Status:     fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			Request:    req,
+		}, nil
+	}
+
+	unmatched := fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, reqBody)
+	r.unmatched = append(r.unmatched, unmatched)
+
+	return nil, fmt.Errorf("no recorded interaction in %s matches request %s", r.path, unmatched)
+}
+
+// Stop writes the cassette when recording, and reports any request that
+// could not be matched when replaying.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		if len(r.unmatched) > 0 {
+			return fmt.Errorf("%d unmatched request(s) replaying %s:\n%s", len(r.unmatched), r.path, strings.Join(r.unmatched, "\n"))
+		}
+
+		return nil
+	}
+
+	if len(r.cassette.Interactions) == 0 {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+
+	return s
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// rawJSON compacts s so that recorded and live bodies compare equal
+// regardless of formatting. Bodies that are not JSON are kept as strings.
+func rawJSON(s string) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(s)); err == nil && buf.Len() > 0 {
+		return buf.Bytes()
+	}
+
+	quoted, _ := json.Marshal(s)
+	return quoted
+}
+
+// redactFields replaces the values of redactedFields at the top level of the
+// JSON object body.
+func redactFields(body json.RawMessage) json.RawMessage {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return body
+	}
+
+	changed := false
+	for _, field := range redactedFields {
+		if _, ok := object[field]; ok {
+			object[field], _ = json.Marshal(redacted)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return body
+	}
+
+	redactedBody, err := json.Marshal(object)
+	if err != nil {
+		return body
+	}
+
+	return redactedBody
+}
+
+// IsNotExist reports whether err means the cassette has not been recorded.
+func IsNotExist(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
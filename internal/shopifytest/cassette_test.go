@@ -0,0 +1,103 @@
+package shopifytest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "TestRecorder.json")
+	ctx := context.Background()
+
+	s, _ := newTestClient(t)
+
+	recorder, err := NewRecorder(path, ModeRecord, s.Client().Transport, StoreAccessToken)
+	require.NoError(t, err)
+
+	recorder.SetApiVersion(StoreApiVersion)
+
+	c := shopify.New(StoreDomain, StoreAccessToken, StoreApiVersion, shopify.WithHTTPClient(recorder.Client()))
+
+	recorded, err := c.Function.List(ctx)
+	require.NoError(t, err)
+
+	created, err := c.Payment.Create(ctx, testFunctionID, &shopify.PaymentNode{Title: "payment", Enabled: true})
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Stop())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), StoreAccessToken)
+
+	t.Run("Replay", func(t *testing.T) {
+		replayer, err := NewRecorder(path, ModeReplay, nil)
+		require.NoError(t, err)
+		assert.Equal(t, StoreApiVersion, replayer.ApiVersion())
+
+		c := shopify.New("other.myshopify.com", "other", StoreApiVersion, shopify.WithHTTPClient(replayer.Client()))
+
+		functions, err := c.Function.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, recorded, functions)
+
+		payment, err := c.Payment.Create(ctx, testFunctionID, &shopify.PaymentNode{Title: "payment", Enabled: true})
+		require.NoError(t, err)
+		assert.Equal(t, created, payment)
+
+		assert.NoError(t, replayer.Stop())
+	})
+
+	t.Run("Unmatched request", func(t *testing.T) {
+		replayer, err := NewRecorder(path, ModeReplay, nil)
+		require.NoError(t, err)
+
+		c := shopify.New(StoreDomain, StoreAccessToken, StoreApiVersion, shopify.WithHTTPClient(replayer.Client()))
+
+		_, err = c.Payment.Create(ctx, testFunctionID, &shopify.PaymentNode{Title: "other", Enabled: true})
+		assert.ErrorContains(t, err, "no recorded interaction")
+
+		assert.ErrorContains(t, replayer.Stop(), "1 unmatched request(s)")
+	})
+
+	t.Run("Missing cassette", func(t *testing.T) {
+		_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil)
+
+		assert.True(t, IsNotExist(err))
+	})
+}
+
+func TestRecorder_OAuth(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TestRecorder_OAuth.json")
+	oauth := shopify.WithOAuth(shopify.OAuth{ClientID: "client-id", ClientSecret: "client-secret"})
+
+	s := NewServer()
+	defer s.Close()
+
+	s.AddOAuthClient("client-id", "client-secret")
+
+	recorder, err := NewRecorder(path, ModeRecord, s.Client().Transport)
+	require.NoError(t, err)
+
+	c := shopify.New(StoreDomain, "", StoreApiVersion, shopify.WithHTTPClient(recorder.Client()), oauth)
+	require.NoError(t, c.Authenticate(context.Background()))
+	require.NoError(t, recorder.Stop())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "client-secret")
+	assert.NotContains(t, string(data), "shpat_oauth")
+
+	replayer, err := NewRecorder(path, ModeReplay, nil)
+	require.NoError(t, err)
+
+	c = shopify.New(StoreDomain, "", StoreApiVersion, shopify.WithHTTPClient(replayer.Client()), oauth)
+	assert.NoError(t, c.Authenticate(context.Background()))
+	assert.NoError(t, replayer.Stop())
+}
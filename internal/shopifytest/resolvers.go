@@ -0,0 +1,900 @@
+package shopifytest
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type discount struct {
+	id                string
+	functionID        string
+	title             string
+	startsAt          string
+	endsAt            string
+	orderDiscounts    bool
+	productDiscounts  bool
+	shippingDiscounts bool
+}
+
+type customization struct {
+	id         string
+	functionID string
+	title      string
+	enabled    bool
+}
+
+type webhook struct {
+	id            string
+	topic         string
+	format        string
+	callbackURL   string
+	arn           string
+	pubSubProject string
+	pubSubTopic   string
+}
+
+type validation struct {
+	id         string
+	functionID string
+	title      string
+	enabled    bool
+}
+
+type cartTransform struct {
+	id         string
+	functionID string
+}
+
+var resolvers map[string]resolver
+
+func init() {
+	resolvers = map[string]resolver{
+		"currentAppInstallation": (*Server).resolveCurrentAppInstallation,
+		"nodes":                  (*Server).resolveNodes,
+		"shop":                   (*Server).resolveShop,
+		"shopifyFunctions":       (*Server).resolveShopifyFunctions,
+
+		"automaticDiscountNodes":     (*Server).resolveAutomaticDiscountNodes,
+		"discountNode":               (*Server).resolveDiscountNode,
+		"discountAutomaticAppCreate": (*Server).resolveDiscountAutomaticAppCreate,
+		"discountAutomaticAppUpdate": (*Server).resolveDiscountAutomaticAppUpdate,
+		"discountAutomaticDelete":    (*Server).resolveDiscountAutomaticDelete,
+
+		"paymentCustomization":       (*Server).resolvePaymentCustomization,
+		"paymentCustomizations":      (*Server).resolvePaymentCustomizations,
+		"paymentCustomizationCreate": (*Server).resolvePaymentCustomizationCreate,
+		"paymentCustomizationUpdate": (*Server).resolvePaymentCustomizationUpdate,
+		"paymentCustomizationDelete": (*Server).resolvePaymentCustomizationDelete,
+
+		"deliveryCustomization":       (*Server).resolveDeliveryCustomization,
+		"deliveryCustomizations":      (*Server).resolveDeliveryCustomizations,
+		"deliveryCustomizationCreate": (*Server).resolveDeliveryCustomizationCreate,
+		"deliveryCustomizationUpdate": (*Server).resolveDeliveryCustomizationUpdate,
+		"deliveryCustomizationDelete": (*Server).resolveDeliveryCustomizationDelete,
+
+		"webhookSubscription":             (*Server).resolveWebhookSubscription,
+		"webhookSubscriptions":            (*Server).resolveWebhookSubscriptions,
+		"pubSubWebhookSubscriptionCreate": (*Server).resolvePubSubWebhookSubscriptionCreate,
+		"pubSubWebhookSubscriptionUpdate": (*Server).resolvePubSubWebhookSubscriptionUpdate,
+		"webhookSubscriptionDelete":       (*Server).resolveWebhookSubscriptionDelete,
+
+		"validations":    (*Server).resolveValidations,
+		"cartTransforms": (*Server).resolveCartTransforms,
+	}
+}
+
+func (s *Server) resolveCurrentAppInstallation(_ map[string]any) (any, error) {
+	scopes := []any{}
+	for _, scope := range s.accessScopes {
+		scopes = append(scopes, map[string]any{
+			"__typename": "AccessScope",
+			"handle":     scope,
+		})
+	}
+
+	return map[string]any{
+		"__typename":   "AppInstallation",
+		"id":           "gid://shopify/AppInstallation/1",
+		"accessScopes": scopes,
+		"app": map[string]any{
+			"__typename": "App",
+			"title":      "shopifytest",
+		},
+	}, nil
+}
+
+func (s *Server) resolveNodes(args map[string]any) (any, error) {
+	ids, _ := args["ids"].([]any)
+
+	nodes := []any{}
+	for _, id := range ids {
+		nodes = append(nodes, s.node(fmt.Sprint(id)))
+	}
+
+	return nodes, nil
+}
+
+// node returns the object with id, or nil when there is none.
+func (s *Server) node(id string) any {
+	if d, ok := s.discounts[id]; ok {
+		return map[string]any{
+			"__typename":        "DiscountAutomaticNode",
+			"id":                d.id,
+			"automaticDiscount": s.discountObject(d),
+		}
+	}
+
+	if c, ok := s.paymentCustomizations[id]; ok {
+		return customizationObject("PaymentCustomization", c)
+	}
+
+	if c, ok := s.deliveryCustomizations[id]; ok {
+		return customizationObject("DeliveryCustomization", c)
+	}
+
+	if w, ok := s.webhooks[id]; ok {
+		return webhookObject(w)
+	}
+
+	return nil
+}
+
+func (s *Server) resolveShop(_ map[string]any) (any, error) {
+	return map[string]any{
+		"__typename":      "Shop",
+		"id":              "gid://shopify/Shop/1",
+		"name":            "shopifytest",
+		"myshopifyDomain": StoreDomain,
+		"primaryDomain": map[string]any{
+			"__typename": "Domain",
+			"host":       StoreDomain,
+		},
+		"currencyCode":                 "USD",
+		"ianaTimezone":                 "America/New_York",
+		"plan":                         map[string]any{"__typename": "ShopPlan", "displayName": "Development"},
+		"enabledPresentmentCurrencies": []any{"USD"},
+	}, nil
+}
+
+func (s *Server) resolveShopifyFunctions(args map[string]any) (any, error) {
+	nodes := []map[string]any{}
+	for _, f := range s.functions {
+		nodes = append(nodes, functionObject(f))
+	}
+
+	return connection(nodes, args), nil
+}
+
+func (s *Server) function(id string) (Function, bool) {
+	for _, f := range s.functions {
+		if f.ID == id {
+			return f, true
+		}
+	}
+
+	return Function{}, false
+}
+
+// inputFunction returns the function a discount or customization input
+// references, by functionHandle if it has one and by functionId otherwise.
+func (s *Server) inputFunction(input map[string]any) (Function, bool) {
+	handle := stringArg(input, "functionHandle")
+	if handle == "" {
+		return s.function(stringArg(input, "functionId"))
+	}
+
+	for _, f := range s.functions {
+		if f.Handle == handle {
+			return f, true
+		}
+	}
+
+	return Function{}, false
+}
+
+func (s *Server) resolveDiscountNode(args map[string]any) (any, error) {
+	d, ok := s.discounts[stringArg(args, "id")]
+	if !ok {
+		return nil, nil
+	}
+
+	return map[string]any{
+		"__typename": "DiscountAutomaticNode",
+		"id":         d.id,
+		"discount":   s.discountObject(d),
+	}, nil
+}
+
+func (s *Server) resolveAutomaticDiscountNodes(args map[string]any) (any, error) {
+	terms := searchTerms(stringArg(args, "query"))
+
+	nodes := []map[string]any{}
+	for _, id := range sortedIDs(s.discounts) {
+		// Every fake discount is active.
+		if v, ok := terms["status"]; ok && v != "active" {
+			continue
+		}
+
+		nodes = append(nodes, map[string]any{
+			"__typename":        "DiscountAutomaticNode",
+			"id":                id,
+			"automaticDiscount": s.discountObject(s.discounts[id]),
+		})
+	}
+
+	return connection(nodes, args), nil
+}
+
+func (s *Server) resolveDiscountAutomaticAppCreate(args map[string]any) (any, error) {
+	input := objectArg(args, "automaticAppDiscount")
+
+	errs := s.userErrorsFor("discountAutomaticAppCreate")
+	if len(errs) == 0 {
+		if _, ok := s.inputFunction(input); !ok {
+			errs = userErrorList(UserError{
+				Field:   []string{"automaticAppDiscount", "functionId"},
+				Message: "Function not found.",
+				Code:    "INVALID",
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{"automaticAppDiscount": nil, "userErrors": errs}, nil
+	}
+
+	f, _ := s.inputFunction(input)
+	d := &discount{
+		id:         fmt.Sprintf("gid://shopify/DiscountAutomaticNode/%d", s.newID()),
+		functionID: f.ID,
+	}
+
+	applyDiscountInput(d, input)
+	s.discounts[d.id] = d
+
+	return map[string]any{"automaticAppDiscount": s.discountObject(d), "userErrors": errs}, nil
+}
+
+func (s *Server) resolveDiscountAutomaticAppUpdate(args map[string]any) (any, error) {
+	errs := s.userErrorsFor("discountAutomaticAppUpdate")
+
+	d, ok := s.discounts[stringArg(args, "id")]
+	if !ok && len(errs) == 0 {
+		errs = notFound("id", "Discount does not exist")
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{"automaticAppDiscount": nil, "userErrors": errs}, nil
+	}
+
+	applyDiscountInput(d, objectArg(args, "automaticAppDiscount"))
+
+	return map[string]any{"automaticAppDiscount": s.discountObject(d), "userErrors": errs}, nil
+}
+
+func (s *Server) resolveDiscountAutomaticDelete(args map[string]any) (any, error) {
+	errs := s.userErrorsFor("discountAutomaticDelete")
+
+	id := stringArg(args, "id")
+	if _, ok := s.discounts[id]; !ok && len(errs) == 0 {
+		errs = notFound("id", "Discount does not exist")
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{"deletedAutomaticDiscountId": nil, "userErrors": errs}, nil
+	}
+
+	delete(s.discounts, id)
+
+	return map[string]any{"deletedAutomaticDiscountId": id, "userErrors": errs}, nil
+}
+
+func applyDiscountInput(d *discount, input map[string]any) {
+	if v, ok := input["title"].(string); ok {
+		d.title = v
+	}
+
+	if v, ok := input["startsAt"].(string); ok {
+		d.startsAt = v
+	}
+
+	if v, ok := input["endsAt"]; ok {
+		d.endsAt, _ = v.(string)
+	}
+
+	if combinesWith, ok := input["combinesWith"].(map[string]any); ok {
+		d.orderDiscounts, _ = combinesWith["orderDiscounts"].(bool)
+		d.productDiscounts, _ = combinesWith["productDiscounts"].(bool)
+		d.shippingDiscounts, _ = combinesWith["shippingDiscounts"].(bool)
+	}
+}
+
+func (s *Server) discountObject(d *discount) map[string]any {
+	var endsAt any
+	if d.endsAt != "" {
+		endsAt = d.endsAt
+	}
+
+	f, _ := s.function(d.functionID)
+
+	return map[string]any{
+		"__typename": "DiscountAutomaticApp",
+		"discountId": d.id,
+		"title":      d.title,
+		"startsAt":   d.startsAt,
+		"endsAt":     endsAt,
+		"status":     "ACTIVE",
+		"combinesWith": map[string]any{
+			"orderDiscounts":    d.orderDiscounts,
+			"productDiscounts":  d.productDiscounts,
+			"shippingDiscounts": d.shippingDiscounts,
+		},
+		"appDiscountType": map[string]any{
+			"__typename": "AppDiscountType",
+			"functionId": d.functionID,
+			"title":      f.Title,
+			"app":        map[string]any{"title": f.AppTitle},
+		},
+	}
+}
+
+func (s *Server) resolvePaymentCustomization(args map[string]any) (any, error) {
+	return s.getCustomization(s.paymentCustomizations, "PaymentCustomization", args)
+}
+
+func (s *Server) resolvePaymentCustomizations(args map[string]any) (any, error) {
+	return listCustomizations(s.paymentCustomizations, "PaymentCustomization", args), nil
+}
+
+func (s *Server) resolvePaymentCustomizationCreate(args map[string]any) (any, error) {
+	return s.createCustomization(s.paymentCustomizations, "PaymentCustomization", "paymentCustomization", args)
+}
+
+func (s *Server) resolvePaymentCustomizationUpdate(args map[string]any) (any, error) {
+	return s.updateCustomization(s.paymentCustomizations, "PaymentCustomization", "paymentCustomization", args)
+}
+
+func (s *Server) resolvePaymentCustomizationDelete(args map[string]any) (any, error) {
+	return s.deleteCustomization(s.paymentCustomizations, "paymentCustomization", args)
+}
+
+func (s *Server) resolveDeliveryCustomization(args map[string]any) (any, error) {
+	return s.getCustomization(s.deliveryCustomizations, "DeliveryCustomization", args)
+}
+
+func (s *Server) resolveDeliveryCustomizations(args map[string]any) (any, error) {
+	return listCustomizations(s.deliveryCustomizations, "DeliveryCustomization", args), nil
+}
+
+func (s *Server) resolveDeliveryCustomizationCreate(args map[string]any) (any, error) {
+	return s.createCustomization(s.deliveryCustomizations, "DeliveryCustomization", "deliveryCustomization", args)
+}
+
+func (s *Server) resolveDeliveryCustomizationUpdate(args map[string]any) (any, error) {
+	return s.updateCustomization(s.deliveryCustomizations, "DeliveryCustomization", "deliveryCustomization", args)
+}
+
+func (s *Server) resolveDeliveryCustomizationDelete(args map[string]any) (any, error) {
+	return s.deleteCustomization(s.deliveryCustomizations, "deliveryCustomization", args)
+}
+
+func (s *Server) getCustomization(
+	store map[string]*customization,
+	typename string,
+	args map[string]any,
+) (any, error) {
+	c, ok := store[stringArg(args, "id")]
+	if !ok {
+		return nil, nil
+	}
+
+	return customizationObject(typename, c), nil
+}
+
+// listCustomizations answers a customizations connection, filtered by the
+// function_id and enabled terms of its search query.
+func listCustomizations(store map[string]*customization, typename string, args map[string]any) any {
+	terms := searchTerms(stringArg(args, "query"))
+
+	nodes := []map[string]any{}
+	for _, id := range sortedIDs(store) {
+		c := store[id]
+
+		if v, ok := terms["function_id"]; ok && v != c.functionID {
+			continue
+		}
+
+		if v, ok := terms["enabled"]; ok && v != strconv.FormatBool(c.enabled) {
+			continue
+		}
+
+		nodes = append(nodes, customizationObject(typename, c))
+	}
+
+	return connection(nodes, args)
+}
+
+func (s *Server) createCustomization(
+	store map[string]*customization,
+	typename string,
+	field string,
+	args map[string]any,
+) (any, error) {
+	input := objectArg(args, field)
+
+	errs := s.userErrorsFor(field + "Create")
+	if len(errs) == 0 {
+		if _, ok := s.inputFunction(input); !ok {
+			errs = userErrorList(UserError{
+				Field:   []string{field, "functionId"},
+				Message: "Function not found.",
+				Code:    "FUNCTION_NOT_FOUND",
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{field: nil, "userErrors": errs}, nil
+	}
+
+	f, _ := s.inputFunction(input)
+	c := &customization{
+		id:         fmt.Sprintf("gid://shopify/%s/%d", typename, s.newID()),
+		functionID: f.ID,
+	}
+
+	applyCustomizationInput(c, input)
+	store[c.id] = c
+
+	return map[string]any{field: customizationObject(typename, c), "userErrors": errs}, nil
+}
+
+func (s *Server) updateCustomization(
+	store map[string]*customization,
+	typename string,
+	field string,
+	args map[string]any,
+) (any, error) {
+	errs := s.userErrorsFor(field + "Update")
+
+	c, ok := store[stringArg(args, "id")]
+	if !ok && len(errs) == 0 {
+		errs = notFound("id", "Customization not found.")
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{field: nil, "userErrors": errs}, nil
+	}
+
+	applyCustomizationInput(c, objectArg(args, field))
+
+	return map[string]any{field: customizationObject(typename, c), "userErrors": errs}, nil
+}
+
+func (s *Server) deleteCustomization(
+	store map[string]*customization,
+	field string,
+	args map[string]any,
+) (any, error) {
+	errs := s.userErrorsFor(field + "Delete")
+
+	id := stringArg(args, "id")
+	if _, ok := store[id]; !ok && len(errs) == 0 {
+		errs = notFound("id", "Customization not found.")
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{"deletedId": nil, "userErrors": errs}, nil
+	}
+
+	delete(store, id)
+
+	return map[string]any{"deletedId": id, "userErrors": errs}, nil
+}
+
+func applyCustomizationInput(c *customization, input map[string]any) {
+	if v, ok := input["title"].(string); ok {
+		c.title = v
+	}
+
+	if v, ok := input["enabled"].(bool); ok {
+		c.enabled = v
+	}
+}
+
+func customizationObject(typename string, c *customization) map[string]any {
+	return map[string]any{
+		"__typename": typename,
+		"id":         c.id,
+		"functionId": c.functionID,
+		"title":      c.title,
+		"enabled":    c.enabled,
+	}
+}
+
+// AddWebhookSubscription stores a subscription as if another tool had
+// created it, and returns its ID. Exactly one of callbackURL, arn or the
+// Pub/Sub project and topic should be set.
+func (s *Server) AddWebhookSubscription(
+	topic string,
+	format string,
+	callbackURL string,
+	arn string,
+	pubSubProject string,
+	pubSubTopic string,
+) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := &webhook{
+		id:            fmt.Sprintf("gid://shopify/WebhookSubscription/%d", s.newID()),
+		topic:         topic,
+		format:        format,
+		callbackURL:   callbackURL,
+		arn:           arn,
+		pubSubProject: pubSubProject,
+		pubSubTopic:   pubSubTopic,
+	}
+
+	s.addWebhook(w)
+	return w.id
+}
+
+func (s *Server) addWebhook(w *webhook) {
+	s.webhooks[w.id] = w
+	s.webhookOrder = append(s.webhookOrder, w.id)
+}
+
+func (s *Server) resolveWebhookSubscription(args map[string]any) (any, error) {
+	w, ok := s.webhooks[stringArg(args, "id")]
+	if !ok {
+		return nil, nil
+	}
+
+	return webhookObject(w), nil
+}
+
+func (s *Server) resolveWebhookSubscriptions(args map[string]any) (any, error) {
+	first := 50
+	if v, ok := args["first"].(int64); ok {
+		first = int(v)
+	}
+
+	topics := []string{}
+	if v, ok := args["topics"].([]any); ok {
+		for _, t := range v {
+			topics = append(topics, fmt.Sprint(t))
+		}
+	}
+
+	after := stringArg(args, "after")
+	started := after == ""
+	nodes := []any{}
+	hasNextPage := false
+	endCursor := ""
+
+	for _, id := range s.webhookOrder {
+		if !started {
+			started = id == after
+			continue
+		}
+
+		w, ok := s.webhooks[id]
+		if !ok {
+			continue
+		}
+
+		if len(topics) > 0 && !slices.Contains(topics, w.topic) {
+			continue
+		}
+
+		if v := stringArg(args, "callbackUrl"); v != "" && v != w.callbackURL {
+			continue
+		}
+
+		if v := stringArg(args, "format"); v != "" && v != w.format {
+			continue
+		}
+
+		if len(nodes) == first {
+			hasNextPage = true
+			break
+		}
+
+		nodes = append(nodes, webhookObject(w))
+		endCursor = w.id
+	}
+
+	var cursor any
+	if endCursor != "" {
+		cursor = endCursor
+	}
+
+	return map[string]any{
+		"nodes": nodes,
+		"pageInfo": map[string]any{
+			"hasNextPage": hasNextPage,
+			"endCursor":   cursor,
+		},
+	}, nil
+}
+
+func (s *Server) resolvePubSubWebhookSubscriptionCreate(args map[string]any) (any, error) {
+	errs := s.userErrorsFor("pubSubWebhookSubscriptionCreate")
+	if len(errs) > 0 {
+		return map[string]any{"webhookSubscription": nil, "userErrors": errs}, nil
+	}
+
+	input := objectArg(args, "webhookSubscription")
+	w := &webhook{
+		id:    fmt.Sprintf("gid://shopify/WebhookSubscription/%d", s.newID()),
+		topic: stringArg(args, "topic"),
+	}
+
+	applyWebhookInput(w, input)
+	s.addWebhook(w)
+
+	return map[string]any{"webhookSubscription": webhookObject(w), "userErrors": errs}, nil
+}
+
+func (s *Server) resolvePubSubWebhookSubscriptionUpdate(args map[string]any) (any, error) {
+	errs := s.userErrorsFor("pubSubWebhookSubscriptionUpdate")
+
+	w, ok := s.webhooks[stringArg(args, "id")]
+	if !ok && len(errs) == 0 {
+		errs = notFound("id", "Webhook subscription does not exist")
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{"webhookSubscription": nil, "userErrors": errs}, nil
+	}
+
+	applyWebhookInput(w, objectArg(args, "webhookSubscription"))
+
+	return map[string]any{"webhookSubscription": webhookObject(w), "userErrors": errs}, nil
+}
+
+func (s *Server) resolveWebhookSubscriptionDelete(args map[string]any) (any, error) {
+	errs := s.userErrorsFor("webhookSubscriptionDelete")
+
+	id := stringArg(args, "id")
+	if _, ok := s.webhooks[id]; !ok && len(errs) == 0 {
+		errs = notFound("id", "Webhook subscription does not exist")
+	}
+
+	if len(errs) > 0 {
+		return map[string]any{"deletedWebhookSubscriptionId": nil, "userErrors": errs}, nil
+	}
+
+	delete(s.webhooks, id)
+
+	return map[string]any{"deletedWebhookSubscriptionId": id, "userErrors": errs}, nil
+}
+
+func applyWebhookInput(w *webhook, input map[string]any) {
+	if v, ok := input["format"].(string); ok {
+		w.format = v
+	}
+
+	if v, ok := input["pubSubProject"].(string); ok {
+		w.pubSubProject = v
+	}
+
+	if v, ok := input["pubSubTopic"].(string); ok {
+		w.pubSubTopic = v
+	}
+}
+
+func webhookObject(w *webhook) map[string]any {
+	endpoint := map[string]any{}
+	switch {
+	case w.callbackURL != "":
+		endpoint["__typename"] = "WebhookHttpEndpoint"
+		endpoint["callbackUrl"] = w.callbackURL
+	case w.arn != "":
+		endpoint["__typename"] = "WebhookEventBridgeEndpoint"
+		endpoint["arn"] = w.arn
+	default:
+		endpoint["__typename"] = "WebhookPubSubEndpoint"
+		endpoint["pubSubProject"] = w.pubSubProject
+		endpoint["pubSubTopic"] = w.pubSubTopic
+	}
+
+	format := w.format
+	if format == "" {
+		format = "JSON"
+	}
+
+	return map[string]any{
+		"__typename": "WebhookSubscription",
+		"id":         w.id,
+		"topic":      w.topic,
+		"format":     format,
+		"endpoint":   endpoint,
+	}
+}
+
+func functionObject(f Function) map[string]any {
+	return map[string]any{
+		"__typename": "ShopifyFunction",
+		"id":         f.ID,
+		"handle":     f.Handle,
+		"title":      f.Title,
+		"apiType":    f.APIType,
+		"app": map[string]any{
+			"__typename": "App",
+			"title":      f.AppTitle,
+		},
+	}
+}
+
+// AddValidation stores a checkout validation backed by functionID, as if the
+// app had created it, and returns its ID.
+func (s *Server) AddValidation(functionID string, title string, enabled bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := &validation{
+		id:         fmt.Sprintf("gid://shopify/Validation/%d", s.newID()),
+		functionID: functionID,
+		title:      title,
+		enabled:    enabled,
+	}
+
+	s.validations[v.id] = v
+	return v.id
+}
+
+func (s *Server) resolveValidations(args map[string]any) (any, error) {
+	nodes := []map[string]any{}
+	for _, id := range sortedIDs(s.validations) {
+		v := s.validations[id]
+
+		nodes = append(nodes, map[string]any{
+			"__typename":      "Validation",
+			"id":              v.id,
+			"title":           v.title,
+			"enabled":         v.enabled,
+			"blockOnFailure":  false,
+			"shopifyFunction": s.validationFunction(v.functionID),
+		})
+	}
+
+	return connection(nodes, args), nil
+}
+
+// validationFunction returns the function a validation is backed by, with
+// only its ID known if it isn't registered.
+func (s *Server) validationFunction(id string) map[string]any {
+	f, ok := s.function(id)
+	if !ok {
+		f = Function{ID: id}
+	}
+
+	return functionObject(f)
+}
+
+// AddCartTransform stores a cart transform backed by functionID, as if the
+// app had created it, and returns its ID.
+func (s *Server) AddCartTransform(functionID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := &cartTransform{
+		id:         fmt.Sprintf("gid://shopify/CartTransform/%d", s.newID()),
+		functionID: functionID,
+	}
+
+	s.cartTransforms[c.id] = c
+	return c.id
+}
+
+func (s *Server) resolveCartTransforms(args map[string]any) (any, error) {
+	nodes := []map[string]any{}
+	for _, id := range sortedIDs(s.cartTransforms) {
+		c := s.cartTransforms[id]
+
+		nodes = append(nodes, map[string]any{
+			"__typename":     "CartTransform",
+			"id":             c.id,
+			"functionId":     c.functionID,
+			"blockOnFailure": false,
+		})
+	}
+
+	return connection(nodes, args), nil
+}
+
+// connection returns the page of nodes that the first and after arguments
+// select, using node IDs as cursors.
+func connection(nodes []map[string]any, args map[string]any) map[string]any {
+	first := 50
+	if v, ok := args["first"].(int64); ok {
+		first = int(v)
+	}
+
+	if after := stringArg(args, "after"); after != "" {
+		i := slices.IndexFunc(nodes, func(node map[string]any) bool {
+			return node["id"] == after
+		})
+
+		nodes = nodes[i+1:]
+	}
+
+	hasNextPage := len(nodes) > first
+	if hasNextPage {
+		nodes = nodes[:first]
+	}
+
+	page := []any{}
+	var endCursor any
+	for _, node := range nodes {
+		page = append(page, node)
+		endCursor = node["id"]
+	}
+
+	return map[string]any{
+		"nodes": page,
+		"pageInfo": map[string]any{
+			"hasNextPage": hasNextPage,
+			"endCursor":   endCursor,
+		},
+	}
+}
+
+// searchTerms parses the key:value terms of a search query. Other search
+// syntax isn't supported.
+func searchTerms(query string) map[string]string {
+	terms := map[string]string{}
+	for _, term := range strings.Fields(query) {
+		if key, value, ok := strings.Cut(term, ":"); ok {
+			terms[key] = value
+		}
+	}
+
+	return terms
+}
+
+// sortedIDs returns the IDs of objects in the order they were created.
+func sortedIDs[T any](objects map[string]T) []string {
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+
+	slices.SortFunc(ids, func(a, b string) int {
+		return cmp.Compare(legacyID(a), legacyID(b))
+	})
+
+	return ids
+}
+
+func legacyID(gid string) int {
+	n, _ := strconv.Atoi(gid[strings.LastIndex(gid, "/")+1:])
+	return n
+}
+
+func notFound(field string, message string) []any {
+	return userErrorList(UserError{
+		Field:   []string{field},
+		Message: message,
+		Code:    "NOT_FOUND",
+	})
+}
+
+func stringArg(args map[string]any, name string) string {
+	v, _ := args[name].(string)
+	return strings.TrimSpace(v)
+}
+
+func objectArg(args map[string]any, name string) map[string]any {
+	v, ok := args[name].(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+
+	return v
+}
@@ -0,0 +1,63 @@
+package shopifytest
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// project shapes value, built from the fake's full objects, into the
+// response the selection set asks for. Objects carry their type in
+// "__typename" so inline fragments can be matched against it.
+func project(doc *ast.QueryDocument, value any, selections ast.SelectionSet) any {
+	switch v := value.(type) {
+	case []any:
+		list := make([]any, 0, len(v))
+		for _, item := range v {
+			list = append(list, project(doc, item, selections))
+		}
+
+		return list
+	case map[string]any:
+		if len(selections) == 0 {
+			return v
+		}
+
+		typename, _ := v["__typename"].(string)
+		object := map[string]any{}
+
+		for _, field := range collectFields(doc, selections, typename) {
+			object[field.Alias] = project(doc, v[field.Name], field.SelectionSet)
+		}
+
+		return object
+	default:
+		return v
+	}
+}
+
+// collectFields flattens selections into the fields that apply to an object
+// of type typename.
+func collectFields(doc *ast.QueryDocument, selections ast.SelectionSet, typename string) []*ast.Field {
+	var fields []*ast.Field
+
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			fields = append(fields, s)
+		case *ast.InlineFragment:
+			if typeMatches(s.TypeCondition, typename) {
+				fields = append(fields, collectFields(doc, s.SelectionSet, typename)...)
+			}
+		case *ast.FragmentSpread:
+			fragment := doc.Fragments.ForName(s.Name)
+			if fragment != nil && typeMatches(fragment.TypeCondition, typename) {
+				fields = append(fields, collectFields(doc, fragment.SelectionSet, typename)...)
+			}
+		}
+	}
+
+	return fields
+}
+
+func typeMatches(condition string, typename string) bool {
+	return condition == "" || condition == typename || condition == "Node"
+}
@@ -0,0 +1,446 @@
+// Package shopifytest provides an in-memory fake of the Shopify Admin GraphQL
+// API, so provider and client tests can run without a real store.
+package shopifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const (
+	StoreDomain      = "example.myshopify.com"
+	StoreAccessToken = "shpat_shopifytest"
+	StoreApiVersion  = "2026-10"
+)
+
+type Server struct {
+	server *httptest.Server
+
+	mu                     sync.Mutex
+	nextID                 int
+	functions              []Function
+	discounts              map[string]*discount
+	paymentCustomizations  map[string]*customization
+	deliveryCustomizations map[string]*customization
+	webhooks               map[string]*webhook
+	webhookOrder           []string
+	validations            map[string]*validation
+	cartTransforms         map[string]*cartTransform
+	userErrors             map[string][]UserError
+	deprecations           map[string]string
+	oauthClients           map[string]string
+	accessTokens           map[string]bool
+	accessScopes           []string
+	throttled              int
+	latency                time.Duration
+	operations             []string
+}
+
+type Function struct {
+	ID       string
+	Handle   string
+	Title    string
+	APIType  string
+	AppTitle string
+}
+
+type UserError struct {
+	Field   []string
+	Message string
+	Code    string
+}
+
+type resolver func(s *Server, args map[string]any) (any, error)
+
+// NewServer starts a fake Admin API. The server accepts any store domain, API
+// version and access token; use Client to route requests to it.
+func NewServer() *Server {
+	s := &Server{
+		nextID:                 1,
+		discounts:              map[string]*discount{},
+		paymentCustomizations:  map[string]*customization{},
+		deliveryCustomizations: map[string]*customization{},
+		webhooks:               map[string]*webhook{},
+		validations:            map[string]*validation{},
+		cartTransforms:         map[string]*cartTransform{},
+		userErrors:             map[string][]UserError{},
+		deprecations:           map[string]string{},
+		oauthClients:           map[string]string{},
+		accessTokens:           map[string]bool{},
+		accessScopes: []string{
+			"read_discounts",
+			"write_discounts",
+			"write_payment_customizations",
+			"write_delivery_customizations",
+		},
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Client returns an HTTP client that sends every request to the fake server,
+// whatever host and scheme it was addressed to.
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.server.URL)
+
+	return &http.Client{
+		Transport: &rewriteTransport{
+			target: target,
+			next:   s.server.Client().Transport,
+		},
+	}
+}
+
+// AddFunction registers a Shopify Function that discounts and customizations
+// can be created from.
+func (s *Server) AddFunction(f Function) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.functions = append(s.functions, f)
+}
+
+// FailNext makes the next call to mutation return errs as userErrors.
+func (s *Server) FailNext(mutation string, errs ...UserError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.userErrors[mutation] = errs
+}
+
+// AddOAuthClient registers app credentials that the token endpoint,
+// /admin/oauth/access_token, exchanges for access tokens.
+func (s *Server) AddOAuthClient(clientID string, clientSecret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.oauthClients[clientID] = clientSecret
+}
+
+// RevokeAccessTokens makes every access token issued by the token endpoint so
+// far fail with 401 Unauthorized.
+func (s *Server) RevokeAccessTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for token := range s.accessTokens {
+		s.accessTokens[token] = false
+	}
+}
+
+// SetAccessScopes replaces the access scopes granted to the app installation,
+// which by default cover every resource the provider manages.
+func (s *Server) SetAccessScopes(scopes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessScopes = scopes
+}
+
+// Deprecate marks the root field as deprecated: responses to operations that
+// use it carry reason in the X-Shopify-API-Deprecated-Reason header.
+func (s *Server) Deprecate(field string, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deprecations[field] = reason
+}
+
+// ThrottleNext makes the next n requests fail with a THROTTLED error.
+func (s *Server) ThrottleNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.throttled = n
+}
+
+// SetLatency delays every Admin API response by d, to simulate a slow or
+// hung store.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// Operations returns the root fields served so far, in order.
+func (s *Server) Operations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.operations...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.URL.Path == "/admin/oauth/access_token" {
+		s.handleAccessToken(w, r)
+		return
+	}
+
+	if !s.authorized(r.Header.Get("X-Shopify-Access-Token")) {
+		http.Error(w, `{"errors":"[API] Invalid API key or access token (unrecognized login or wrong password)"}`, http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	latency := s.latency
+	s.mu.Unlock()
+
+	select {
+	case <-time.After(latency):
+	case <-r.Context().Done():
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.throttled > 0 {
+		s.throttled--
+		writeJSON(w, map[string]any{
+			"errors": []any{
+				map[string]any{
+					"message":    "Throttled",
+					"extensions": map[string]any{"code": "THROTTLED"},
+				},
+			},
+			"extensions": costExtensions(0),
+		})
+
+		return
+	}
+
+	served := len(s.operations)
+
+	data, err := s.execute(body.Query, body.Variables)
+	for _, field := range s.operations[served:] {
+		if reason, ok := s.deprecations[field]; ok {
+			w.Header().Add("X-Shopify-API-Deprecated-Reason", reason)
+		}
+	}
+
+	if err != nil {
+		writeJSON(w, map[string]any{
+			"errors": []any{map[string]any{"message": err.Error()}},
+		})
+
+		return
+	}
+
+	writeJSON(w, map[string]any{
+		"data":       data,
+		"extensions": costExtensions(len(data)),
+	})
+}
+
+// authorized accepts any access token except those issued by the token
+// endpoint and since revoked.
+func (s *Server) authorized(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	valid, issued := s.accessTokens[token]
+	return token != "" && (!issued || valid)
+}
+
+func (s *Server) handleAccessToken(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ClientID         string `json:"client_id"`
+		ClientSecret     string `json:"client_secret"`
+		GrantType        string `json:"grant_type"`
+		SubjectToken     string `json:"subject_token"`
+		SubjectTokenType string `json:"subject_token_type"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		oauthError(w, "invalid_request", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if secret, ok := s.oauthClients[body.ClientID]; !ok || secret != body.ClientSecret {
+		oauthError(w, "invalid_client", "Client credentials are invalid")
+		return
+	}
+
+	res := map[string]any{
+		"access_token": fmt.Sprintf("shpat_oauth_%d", s.newID()),
+		"scope":        "read_discounts,write_discounts,write_payment_customizations,write_delivery_customizations",
+	}
+
+	switch body.GrantType {
+	case "client_credentials":
+		res["expires_in"] = 86399
+	case "urn:ietf:params:oauth:grant-type:token-exchange":
+		if body.SubjectToken == "" || body.SubjectTokenType != "urn:ietf:params:oauth:token-type:id_token" {
+			oauthError(w, "invalid_subject_token", "Session token is missing or invalid")
+			return
+		}
+	default:
+		oauthError(w, "unsupported_grant_type", fmt.Sprintf("Grant type %q is not supported", body.GrantType))
+		return
+	}
+
+	s.accessTokens[res["access_token"].(string)] = true
+	writeJSON(w, res)
+}
+
+func oauthError(w http.ResponseWriter, code string, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func (s *Server) execute(query string, vars map[string]any) (map[string]any, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Operations) != 1 {
+		return nil, fmt.Errorf("expected exactly one operation, got %d", len(doc.Operations))
+	}
+
+	op := doc.Operations[0]
+	data := map[string]any{}
+
+	for _, field := range collectFields(doc, op.SelectionSet, "") {
+		resolve, ok := resolvers[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("Field '%s' doesn't exist on type '%s'", field.Name, rootType(op.Operation))
+		}
+
+		args := map[string]any{}
+		for _, arg := range field.Arguments {
+			v, err := arg.Value.Value(vars)
+			if err != nil {
+				return nil, err
+			}
+
+			args[arg.Name] = v
+		}
+
+		s.operations = append(s.operations, field.Name)
+
+		result, err := resolve(s, args)
+		if err != nil {
+			return nil, err
+		}
+
+		data[field.Alias] = project(doc, result, field.SelectionSet)
+	}
+
+	return data, nil
+}
+
+// userErrorsFor returns, and clears, the userErrors queued for mutation.
+func (s *Server) userErrorsFor(mutation string) []any {
+	errs := s.userErrors[mutation]
+	delete(s.userErrors, mutation)
+
+	return userErrorList(errs...)
+}
+
+func (s *Server) newID() int {
+	id := s.nextID
+	s.nextID++
+
+	return id
+}
+
+func rootType(op ast.Operation) string {
+	if op == ast.Mutation {
+		return "Mutation"
+	}
+
+	return "QueryRoot"
+}
+
+func userErrorList(errs ...UserError) []any {
+	list := []any{}
+	for _, e := range errs {
+		field := []any{}
+		for _, f := range e.Field {
+			field = append(field, f)
+		}
+
+		list = append(list, map[string]any{
+			"field":   field,
+			"message": e.Message,
+			"code":    e.Code,
+		})
+	}
+
+	return list
+}
+
+func costExtensions(fields int) map[string]any {
+	cost := 1 + fields*10
+
+	return map[string]any{
+		"cost": map[string]any{
+			"requestedQueryCost": cost,
+			"actualQueryCost":    cost,
+			"throttleStatus": map[string]any{
+				"maximumAvailable":   2000,
+				"currentlyAvailable": 2000 - cost,
+				"restoreRate":        100,
+			},
+		},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,464 @@
+package shopifytest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/shopify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFunctionID = "07224386-3c16-4f9e-b8ba-da049b6afc66"
+
+func newTestClient(t *testing.T) (*Server, *shopify.ShopifyAdminClinetImpl) {
+	t.Helper()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddFunction(Function{
+		ID:       testFunctionID,
+		Title:    "product-discount",
+		APIType:  "product_discounts",
+		AppTitle: "tf-testing",
+	})
+
+	// Send every Get on its own so the tests cover each root field.
+	c := shopify.New(StoreDomain, StoreAccessToken, StoreApiVersion,
+		shopify.WithHTTPClient(s.Client()),
+		shopify.WithReadCoalescing(0),
+	)
+
+	return s, c
+}
+
+func TestServer_Functions(t *testing.T) {
+	_, c := newTestClient(t)
+
+	functions, err := c.Function.List(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, functions.Nodes, 1)
+	assert.Equal(t, testFunctionID, functions.Nodes[0].ID)
+	assert.Equal(t, "product_discounts", functions.Nodes[0].APIType)
+	assert.Equal(t, "tf-testing", functions.Nodes[0].APPName)
+}
+
+func TestServer_Discount(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+
+	created, err := c.Discount.Create(ctx, testFunctionID, &shopify.DiscountNode{
+		Title:        "test_discount",
+		StartsAt:     "2024-01-01T00:00:00Z",
+		CombinesWith: &shopify.DiscountCombinesWith{OrderDiscounts: true},
+	})
+
+	require.NoError(t, err)
+	assert.Regexp(t, `^gid://shopify/DiscountAutomaticNode/\d+$`, created.ID)
+	assert.Equal(t, testFunctionID, created.FunctionID)
+	assert.Empty(t, created.EndsAt)
+
+	updated, err := c.Discount.Update(ctx, &shopify.DiscountNode{
+		ID:           created.ID,
+		Title:        "updated_discount",
+		StartsAt:     "2024-01-01T00:00:00Z",
+		EndsAt:       "2024-02-01T00:00:00Z",
+		CombinesWith: &shopify.DiscountCombinesWith{ShippingDiscounts: true},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "updated_discount", updated.Title)
+	assert.Equal(t, "2024-02-01T00:00:00Z", updated.EndsAt)
+	assert.False(t, updated.CombinesWith.OrderDiscounts)
+	assert.True(t, updated.CombinesWith.ShippingDiscounts)
+
+	read, err := c.Discount.Get(ctx, created.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, updated, read)
+
+	deleted, err := c.Discount.Delete(ctx, created.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, deleted.ID)
+
+	read, err = c.Discount.Get(ctx, created.ID)
+
+	require.NoError(t, err)
+	assert.Empty(t, read.ID)
+
+	assert.Equal(t, []string{
+		"discountAutomaticAppCreate",
+		"discountAutomaticAppUpdate",
+		"discountNode",
+		"discountAutomaticDelete",
+		"discountNode",
+	}, s.Operations())
+}
+
+func TestServer_Customizations(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	payment, err := c.Payment.Create(ctx, testFunctionID, &shopify.PaymentNode{Title: "payment", Enabled: true})
+
+	require.NoError(t, err)
+	assert.Regexp(t, `^gid://shopify/PaymentCustomization/\d+$`, payment.ID)
+
+	delivery, err := c.Delivery.Create(ctx, testFunctionID, &shopify.DeliveryNode{Title: "delivery"})
+
+	require.NoError(t, err)
+	assert.Regexp(t, `^gid://shopify/DeliveryCustomization/\d+$`, delivery.ID)
+
+	payment, err = c.Payment.Update(ctx, &shopify.PaymentNode{ID: payment.ID, Title: "updated", Enabled: false})
+
+	require.NoError(t, err)
+	assert.Equal(t, "updated", payment.Title)
+	assert.False(t, payment.Enabled)
+
+	read, err := c.Payment.Get(ctx, payment.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, testFunctionID, read.FunctionID)
+
+	_, err = c.Delivery.Get(ctx, payment.ID)
+
+	require.NoError(t, err)
+
+	deleted, err := c.Delivery.Delete(ctx, delivery.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, delivery.ID, deleted.ID)
+}
+
+func TestServer_Lists(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	discount, err := c.Discount.Create(ctx, testFunctionID, &shopify.DiscountNode{
+		Title:        "discount",
+		StartsAt:     "2024-01-01T00:00:00Z",
+		CombinesWith: &shopify.DiscountCombinesWith{},
+	})
+	require.NoError(t, err)
+
+	enabled, err := c.Payment.Create(ctx, testFunctionID, &shopify.PaymentNode{Title: "enabled", Enabled: true})
+	require.NoError(t, err)
+
+	disabled, err := c.Payment.Create(ctx, testFunctionID, &shopify.PaymentNode{Title: "disabled"})
+	require.NoError(t, err)
+
+	delivery, err := c.Delivery.Create(ctx, testFunctionID, &shopify.DeliveryNode{Title: "delivery"})
+	require.NoError(t, err)
+
+	discounts, err := c.Discount.List(ctx, &shopify.DiscountFilter{Status: "ACTIVE"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []shopify.DiscountNode{*discount}, discounts)
+
+	discounts, err = c.Discount.List(ctx, &shopify.DiscountFilter{Status: "EXPIRED"})
+
+	require.NoError(t, err)
+	assert.Empty(t, discounts)
+
+	payments, err := c.Payment.List(ctx, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []shopify.PaymentNode{*enabled, *disabled}, payments)
+
+	on := true
+	payments, err = c.Payment.List(ctx, &shopify.CustomizationFilter{FunctionID: testFunctionID, Enabled: &on})
+
+	require.NoError(t, err)
+	assert.Equal(t, []shopify.PaymentNode{*enabled}, payments)
+
+	deliveries, err := c.Delivery.List(ctx, &shopify.CustomizationFilter{FunctionID: "other"})
+
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+
+	deliveries, err = c.Delivery.List(ctx, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []shopify.DeliveryNode{*delivery}, deliveries)
+}
+
+func TestConnection(t *testing.T) {
+	nodes := []map[string]any{{"id": "a"}, {"id": "b"}, {"id": "c"}}
+
+	page := connection(nodes, map[string]any{"first": int64(2)})
+
+	assert.Equal(t, []any{nodes[0], nodes[1]}, page["nodes"])
+	assert.Equal(t, map[string]any{"hasNextPage": true, "endCursor": "b"}, page["pageInfo"])
+
+	page = connection(nodes, map[string]any{"first": int64(2), "after": "b"})
+
+	assert.Equal(t, []any{nodes[2]}, page["nodes"])
+	assert.Equal(t, map[string]any{"hasNextPage": false, "endCursor": "c"}, page["pageInfo"])
+}
+
+func TestServer_FunctionHandle(t *testing.T) {
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddFunction(Function{
+		ID:       testFunctionID,
+		Handle:   "payment-customization",
+		Title:    "payment-customization",
+		APIType:  "payment_customization",
+		AppTitle: "tf-testing",
+	})
+
+	c := shopify.New(StoreDomain, StoreAccessToken, "2025-04", shopify.WithHTTPClient(s.Client()))
+	ctx := context.Background()
+
+	payment, err := c.Payment.Create(ctx, "", &shopify.PaymentNode{Title: "payment", FunctionHandle: "payment-customization"})
+
+	require.NoError(t, err)
+	assert.Equal(t, testFunctionID, payment.FunctionID)
+
+	payment, err = c.Payment.Create(ctx, "", &shopify.PaymentNode{Title: "payment", FunctionHandle: "missing"})
+
+	require.NoError(t, err)
+	assert.Empty(t, payment.ID)
+}
+
+func TestServer_Webhooks(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+
+	httpID := s.AddWebhookSubscription("ORDERS_CREATE", "JSON", "https://example.com/hooks", "", "", "")
+
+	hook, err := c.PubsubWebhook.Create(ctx, &shopify.PubsubWebhook{
+		Topic:         "DISCOUNTS_CREATE",
+		Format:        "JSON",
+		PubSubProject: "project",
+		PubSubTopic:   "topic",
+	})
+
+	require.NoError(t, err)
+	assert.Regexp(t, `^gid://shopify/WebhookSubscription/\d+$`, hook.ID)
+	assert.Equal(t, "project", hook.PubSubProject)
+
+	subscriptions, err := c.WebhookSubscription.List(ctx, nil)
+
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, httpID, subscriptions[0].ID)
+	assert.Equal(t, shopify.WebhookEndpointHTTP, subscriptions[0].EndpointType)
+	assert.Equal(t, shopify.WebhookEndpointPubSub, subscriptions[1].EndpointType)
+
+	subscriptions, err = c.WebhookSubscription.List(ctx, &shopify.WebhookSubscriptionFilter{
+		Topics: []string{"DISCOUNTS_CREATE"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, hook.ID, subscriptions[0].ID)
+
+	require.NoError(t, c.PubsubWebhook.Delete(ctx, hook.ID))
+
+	read, err := c.PubsubWebhook.Get(ctx, hook.ID)
+
+	require.NoError(t, err)
+	assert.Empty(t, read.ID)
+}
+
+func TestServer_Throttle(t *testing.T) {
+	s, c := newTestClient(t)
+
+	s.ThrottleNext(1)
+
+	_, err := c.Function.List(context.Background())
+	assert.ErrorContains(t, err, "Throttled")
+
+	_, err = c.Function.List(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestServer_UnknownField(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	_, err := s.execute(`query { unknownField { id } }`, nil)
+
+	assert.ErrorContains(t, err, "Field 'unknownField' doesn't exist on type 'QueryRoot'")
+}
+
+func TestServer_UserErrors(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.FailNext("paymentCustomizationCreate", UserError{
+		Field:   []string{"paymentCustomization", "title"},
+		Message: "Title is too long",
+		Code:    "INVALID",
+	})
+
+	data, err := s.execute(`
+		mutation {
+			paymentCustomizationCreate(paymentCustomization: { functionId: "x", title: "t", enabled: true }) {
+				paymentCustomization { id }
+				userErrors { field message code }
+			}
+		}
+	`, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"paymentCustomizationCreate": map[string]any{
+			"paymentCustomization": nil,
+			"userErrors": []any{
+				map[string]any{
+					"field":   []any{"paymentCustomization", "title"},
+					"message": "Title is too long",
+					"code":    "INVALID",
+				},
+			},
+		},
+	}, data)
+
+	data, err = s.execute(`
+		mutation {
+			paymentCustomizationCreate(paymentCustomization: { functionId: "x", title: "t", enabled: true }) {
+				userErrors { code }
+			}
+		}
+	`, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"paymentCustomizationCreate": map[string]any{
+			"userErrors": []any{map[string]any{"code": "FUNCTION_NOT_FOUND"}},
+		},
+	}, data)
+}
+
+func TestServer_Deprecate(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := shopify.WithDeprecationScope(context.Background(), "test")
+
+	s.Deprecate("shopifyFunctions", "Use functions instead.")
+
+	_, err := c.Function.List(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []shopify.Deprecation{
+		{Operation: "shopifyFunctions", Reason: "Use functions instead."},
+	}, c.TakeDeprecations("test"))
+}
+
+func TestServer_OAuth(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.AddOAuthClient("client-id", "client-secret")
+
+	c := shopify.New(StoreDomain, "", StoreApiVersion,
+		shopify.WithHTTPClient(s.Client()),
+		shopify.WithOAuth(shopify.OAuth{ClientID: "client-id", ClientSecret: "client-secret"}),
+	)
+
+	require.NoError(t, c.Authenticate(context.Background()))
+
+	s.RevokeAccessTokens()
+
+	_, err := c.Function.List(context.Background())
+	require.NoError(t, err)
+
+	bad := shopify.New(StoreDomain, "", StoreApiVersion,
+		shopify.WithHTTPClient(s.Client()),
+		shopify.WithOAuth(shopify.OAuth{ClientID: "client-id", ClientSecret: "wrong", SessionToken: "session"}),
+	)
+
+	assert.ErrorContains(t, bad.Authenticate(context.Background()), "Client credentials are invalid")
+}
+
+func TestServer_AppInstallation(t *testing.T) {
+	s, c := newTestClient(t)
+
+	s.SetAccessScopes("write_discounts")
+
+	installation, err := c.AppInstallation.Get(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "gid://shopify/AppInstallation/1", installation.ID)
+	assert.Equal(t, "shopifytest", installation.AppTitle)
+	assert.Equal(t, []string{"write_discounts"}, installation.AccessScopes)
+}
+
+func TestServer_Shop(t *testing.T) {
+	_, c := newTestClient(t)
+
+	shop, err := c.Shop.Get(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "gid://shopify/Shop/1", shop.ID)
+	assert.Equal(t, StoreDomain, shop.PrimaryDomain)
+	assert.Equal(t, "America/New_York", shop.IanaTimezone)
+	assert.Equal(t, []string{"USD"}, shop.EnabledPresentmentCurrencies)
+}
+
+func TestServer_Nodes(t *testing.T) {
+	s, _ := newTestClient(t)
+	c := shopify.New(StoreDomain, StoreAccessToken, StoreApiVersion, shopify.WithHTTPClient(s.Client()))
+	ctx := context.Background()
+
+	discount, err := c.Discount.Create(ctx, testFunctionID, &shopify.DiscountNode{
+		Title:        "discount",
+		StartsAt:     "2024-01-01T00:00:00Z",
+		CombinesWith: &shopify.DiscountCombinesWith{},
+	})
+	require.NoError(t, err)
+
+	payment, err := c.Payment.Create(ctx, testFunctionID, &shopify.PaymentNode{Title: "payment", Enabled: true})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+
+		got, err := c.Discount.Get(ctx, discount.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, discount, got)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		got, err := c.Payment.Get(ctx, payment.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, payment, got)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		got, err := c.Delivery.Get(ctx, "gid://shopify/DeliveryCustomization/404")
+		assert.NoError(t, err)
+		assert.Equal(t, "", got.ID)
+	}()
+
+	wg.Wait()
+
+	assert.Equal(t, []string{"discountAutomaticAppCreate", "paymentCustomizationCreate", "nodes"}, s.Operations())
+}
+
+func TestServer_Latency(t *testing.T) {
+	s, c := newTestClient(t)
+
+	s.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Function.List(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
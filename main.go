@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"github.com/pseudomonarchia/terraform-provider-shopify/internal/export"
 	"github.com/pseudomonarchia/terraform-provider-shopify/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -28,6 +30,16 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "debug mode")
 	flag.Parse()
 
+	// Terraform starts the provider without arguments, so subcommands
+	// don't get in its way.
+	if flag.Arg(0) == "export" {
+		if err := export.Run(context.Background(), flag.Args()[1:], version, os.Stderr); err != nil {
+			log.Fatal(err.Error())
+		}
+
+		return
+	}
+
 	opts := providerserver.ServeOpts{
 		Address: "registry.terraform.io/pseudomonarchia/terraform-provider-shopify",
 		Debug:   debug,